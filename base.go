@@ -0,0 +1,106 @@
+package gostructui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bases maps the values of the smbase tag to the bases they name.
+var bases = map[string]int{"dec": 10, "hex": 16, "bin": 2}
+
+// parseBase reads the value of an smbase tag.
+func parseBase(tag string) (int, error) {
+	base, ok := bases[tag]
+	if !ok {
+		return 0, fmt.Errorf("unknown base %q (want hex, bin or dec)", tag)
+	}
+	return base, nil
+}
+
+// numBase returns the base a numeric field is shown and typed in,
+// which is decimal unless the field is tagged smbase.
+func (f *menuField) numBase() int {
+	if f.base == 0 {
+		return 10
+	}
+	return f.base
+}
+
+// basePrefix returns the prefix marking numbers in the base of the
+// field, as in "0xff", or an empty string for decimal.
+func (f *menuField) basePrefix() string {
+	switch f.numBase() {
+	case 16:
+		return "0x"
+	case 2:
+		return "0b"
+	default:
+		return ""
+	}
+}
+
+// withPrefix places the prefix of the base of the field in front
+// of the digits s, behind any leading sign.
+func (f *menuField) withPrefix(s string) string {
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		return "-" + f.basePrefix() + rest
+	}
+	return f.basePrefix() + s
+}
+
+// formatNumber returns the value of a numeric field as text,
+// in its base and with the prefix of that base.
+func (f *menuField) formatNumber() string {
+	if f.kind == FieldUint {
+		return f.withPrefix(strconv.FormatUint(f.u, f.numBase()))
+	}
+	return f.withPrefix(strconv.FormatInt(f.i, f.numBase()))
+}
+
+// isBaseDigits reports whether s is made up of digits of the base
+// of the field only, in either case for hexadecimal.
+func (f *menuField) isBaseDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range strings.ToLower(s) {
+		if d := strings.IndexRune("0123456789abcdef", r); d < 0 || d >= f.numBase() {
+			return false
+		}
+	}
+	return true
+}
+
+// parseInt parses s as a value for an int field, as formatNumber
+// writes it. Numbers without the prefix of the base of the field
+// are read as decimal, so values from JSON, YAML or the environment
+// stay readable.
+func (f *menuField) parseInt(s string) (int64, error) {
+	digits, base := f.splitBase(s)
+	return strconv.ParseInt(digits, base, f.bits)
+}
+
+// parseUint parses s as a value for an unsigned int field,
+// as parseInt does for int fields.
+func (f *menuField) parseUint(s string) (uint64, error) {
+	digits, base := f.splitBase(s)
+	return strconv.ParseUint(digits, base, f.bits)
+}
+
+// splitBase returns s stripped of the prefix of the base of the
+// field, keeping any leading sign, along with the base to parse
+// it in: that of the field if s has the prefix, or else decimal.
+func (f *menuField) splitBase(s string) (string, int) {
+	if f.numBase() == 10 {
+		return s, 10
+	}
+	sign, rest := "", s
+	if r, ok := strings.CutPrefix(s, "-"); ok {
+		sign, rest = "-", r
+	}
+	if digits, ok := strings.CutPrefix(strings.ToLower(rest), f.basePrefix()); ok {
+		return sign + digits, f.numBase()
+	}
+	return s, 10
+}
@@ -0,0 +1,97 @@
+package gostructui
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// parseBoundTag reads a numeric bound from the given tag of
+// the struct field, returning nil if the tag is not present.
+func parseBoundTag(field reflect.StructField, tag string) (*int64, error) {
+	tagVal := field.Tag.Get(tag)
+	if tagVal == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseInt(tagVal, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s tag on field '%s': %w", tag, field.Name, err)
+	}
+	return &v, nil
+}
+
+// uintAsInt64 converts an unsigned value for comparison against
+// the bounds of a field. Values too large for an int64 lie beyond
+// any bound, so they are capped at math.MaxInt64.
+func uintAsInt64(u uint64) int64 {
+	return int64(min(u, math.MaxInt64))
+}
+
+// clamp returns v pulled within the bounds of the field.
+func (f *menuField) clamp(v int64) int64 {
+	if f.maxVal != nil && v > *f.maxVal {
+		return *f.maxVal
+	}
+	if f.minVal != nil && v < *f.minVal {
+		return *f.minVal
+	}
+	return v
+}
+
+// checkRange returns an error if v lies outside the bounds of the field.
+func (f *menuField) checkRange(v int64) error {
+	if f.clamp(v) != v {
+		return fmt.Errorf("value of field '%s' must be in range %s", f.getFieldName(), f.rangeHint())
+	}
+	return nil
+}
+
+// clampBuf pulls a number being typed back within the bounds
+// of the field once it has grown past them. Numbers short of
+// a bound are left alone, as more digits may yet bring them
+// within range; those are caught when the edit is committed.
+// A digit that would take the number past what the type of
+// the field can hold is dropped instead.
+func (f *menuField) clampBuf() {
+	v, err := f.parseBuf()
+	for errors.Is(err, strconv.ErrRange) {
+		f.editBuf = f.editBuf[:len(f.editBuf)-1]
+		v, err = f.parseBuf()
+	}
+	if err != nil {
+		return
+	}
+	switch {
+	case f.maxVal != nil && v > *f.maxVal && v > 0:
+		f.editBuf = strconv.FormatInt(*f.maxVal, f.numBase())
+	case f.minVal != nil && v < *f.minVal && v < 0:
+		f.editBuf = strconv.FormatInt(*f.minVal, f.numBase())
+	}
+}
+
+// parseBuf parses the number being typed into a numeric field
+// as the type of the field, returned as an int64 for comparison
+// against the bounds of the field.
+func (f *menuField) parseBuf() (int64, error) {
+	if f.kind == FieldUint {
+		u, err := strconv.ParseUint(f.editBuf, f.numBase(), f.bits)
+		return uintAsInt64(u), err
+	}
+	return strconv.ParseInt(f.editBuf, f.numBase(), f.bits)
+}
+
+// rangeHint describes the bounds of the field for display,
+// or returns an empty string if the field has none.
+func (f *menuField) rangeHint() string {
+	switch {
+	case f.minVal != nil && f.maxVal != nil:
+		return fmt.Sprintf("%d–%d", *f.minVal, *f.maxVal)
+	case f.minVal != nil:
+		return fmt.Sprintf("≥ %d", *f.minVal)
+	case f.maxVal != nil:
+		return fmt.Sprintf("≤ %d", *f.maxVal)
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package gostructui
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// copyField copies the value of the field under the cursor to the
+// clipboard, as it would be typed, so that masked values are copied
+// in full rather than as the mask shown. Users are told once the
+// value is copied, or why it couldn't be. Terminals don't answer an
+// OSC 52 sequence, so without Settings.CopyToClipboard users are
+// only told the value was sent to the clipboard.
+func (m *TModelStructMenu) copyField() tea.Cmd {
+	if !m.cursorInRange() {
+		return nil
+	}
+	f := m.getFieldUnderCursor()
+	text := f.text()
+	if m.Settings.CopyToClipboard != nil {
+		if err := m.Settings.CopyToClipboard(text); err != nil {
+			f.notice = "could not copy: " + err.Error()
+			return nil
+		}
+		f.notice = "copied!"
+		return nil
+	}
+	f.notice = "sent to clipboard"
+	return func() tea.Msg {
+		// stderr keeps the escape sequence clear of
+		// the frames bubbletea renders to stdout
+		termenv.NewOutput(os.Stderr).Copy(text)
+		return nil
+	}
+}
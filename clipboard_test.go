@@ -0,0 +1,46 @@
+package gostructui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCopyConfirmsOnlyWhatSucceeded(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		copy  func(string) error
+		toast string
+	}{
+		{"osc52", nil, "sent to clipboard"},
+		{"ok", func(string) error { return nil }, "copied!"},
+		{"failed", func(string) error { return errors.New("no clipboard") }, "could not copy: no clipboard"},
+	} {
+		obj := struct {
+			Password string `smmask:"true"`
+		}{Password: "hunter2"}
+		settings := &MenuSettings{}
+		settings.Init()
+		var copied string
+		if tt.copy != nil {
+			settings.CopyToClipboard = func(text string) error {
+				copied = text
+				return tt.copy(text)
+			}
+		}
+		m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m = SendKeys(m, "y")
+		if m.toast != tt.toast {
+			t.Errorf("%s: toast = %q, want %q", tt.name, m.toast, tt.toast)
+		}
+		if tt.copy != nil && copied != "hunter2" {
+			t.Errorf("%s: copied %q, want the unmasked value", tt.name, copied)
+		}
+		if !strings.Contains(m.View(), tt.toast) {
+			t.Errorf("%s: View doesn't show the toast:\n%s", tt.name, m.View())
+		}
+	}
+}
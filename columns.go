@@ -0,0 +1,123 @@
+package gostructui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// columnGap is the number of cells left between columns of fields.
+const columnGap = 3
+
+// columns returns the number of columns the fields are laid out
+// in: as many as Settings.Columns asks for, but no more than fit
+// the width of the terminal. Until that width is known, the fields
+// take a single column.
+func (m TModelStructMenu) columns() int {
+	n := m.Settings.Columns
+	if n <= 1 || m.width <= 0 {
+		return 1
+	}
+	width := m.columnWidth()
+	for n > 1 && n*width-columnGap > m.width {
+		n--
+	}
+	return n
+}
+
+// columnWidth returns the width each column of fields takes,
+// which is that of the widest row, description or error shown,
+// along with the gap to the next column.
+func (m TModelStructMenu) columnWidth() int {
+	width := 0
+	for i := range m.menuFields {
+		if !m.isHidden(i) {
+			width = max(width, lipgloss.Width(m.fieldBlock(i)))
+		}
+	}
+	return width + columnGap
+}
+
+// sections splits the indices of the fields shown into runs
+// sharing a group, each of which is laid out in columns of its
+// own beneath its heading.
+func (m TModelStructMenu) sections() [][]int {
+	var sections [][]int
+	for i := range m.menuFields {
+		if m.isHidden(i) {
+			continue
+		}
+		if n := len(sections); n > 0 && m.getFieldAtIndex(sections[n-1][0]).group == m.getFieldAtIndex(i).group {
+			sections[n-1] = append(sections[n-1], i)
+		} else {
+			sections = append(sections, []int{i})
+		}
+	}
+	return sections
+}
+
+// cellPos is where the row of a field is found in the fields view.
+type cellPos struct {
+	line, col int
+	shown     bool
+}
+
+// layout returns where the row of each field is found in the
+// fields view, as laid out by fieldsView. Fields in a column
+// follow each other top to bottom, and the rows of a section are
+// as tall as the tallest field in them, counting the lines of text
+// areas, descriptions and errors.
+func (m TModelStructMenu) layout() []cellPos {
+	pos := make([]cellPos, len(m.menuFields))
+	cols := m.columns()
+	line := 0
+	for _, section := range m.sections() {
+		line += strings.Count(m.groupHeader(section[0]), "\n")
+		rows := (len(section) + cols - 1) / cols
+		for r := range rows {
+			height := 0
+			for c := range cols {
+				if k := c*rows + r; k < len(section) {
+					i := section[k]
+					pos[i] = cellPos{line: line, col: c, shown: true}
+					height = max(height, strings.Count(m.fieldBlock(i), "\n"))
+				}
+			}
+			line += height
+		}
+	}
+	return pos
+}
+
+// moveColumn moves the cursor to the field beside it in the column
+// step columns over, within its section. The cursor stays put if
+// there is no such field.
+func (m *TModelStructMenu) moveColumn(step int) {
+	cols := m.columns()
+	if cols <= 1 || !m.cursorInRange() {
+		return
+	}
+	for _, section := range m.sections() {
+		rows := (len(section) + cols - 1) / cols
+		for k, i := range section {
+			if i != m.cursor {
+				continue
+			}
+			if k += step * rows; k >= 0 && k < len(section) {
+				m.getFieldUnderCursor().errBuf = ""
+				m.cursor = section[k]
+			}
+			return
+		}
+	}
+}
+
+// padBlock pads every line of s with spaces up to the given width
+// in terminal cells.
+func padBlock(s string, width int) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = padRight(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
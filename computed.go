@@ -0,0 +1,42 @@
+package gostructui
+
+import "fmt"
+
+// RegisterComputed derives the value of the named field from the
+// rest of the menu, as in a FullName built from FirstName and LastName.
+// The function is called with the menu after every update, and should
+// read the values it depends on through FieldValue. It must return a
+// value of the same Go type as the field. Computed fields are shown
+// to the user, but cannot be edited; ParseStruct writes back whatever
+// value was last computed.
+func (m *TModelStructMenu) RegisterComputed(fieldName string, fn func(m *TModelStructMenu) any) error {
+	f := m.getFieldByName(fieldName)
+	if f == nil {
+		return fmt.Errorf("no field '%s' exposed by menu", fieldName)
+	}
+	f.compute = fn
+	err := m.recompute()
+	m.checkValidity()
+	return err
+}
+
+// recompute refreshes the values of all computed fields.
+// A computed value that cannot be assigned to its field
+// is reported on that field, and the first such error
+// is returned.
+func (m *TModelStructMenu) recompute() error {
+	var firstErr error
+	for i := range m.menuFields {
+		f := m.getFieldAtIndex(i)
+		if f.compute == nil {
+			continue
+		}
+		if err := f.setValue(f.compute(m)); err != nil {
+			f.errBuf = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
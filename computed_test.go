@@ -0,0 +1,62 @@
+package gostructui
+
+import "testing"
+
+type nameForm struct {
+	FirstName string
+	LastName  string
+	FullName  string
+}
+
+func newNameMenu(t *testing.T, obj *nameForm) TModelStructMenu {
+	t.Helper()
+	m := newTestMenu(t, obj)
+	err := m.RegisterComputed("FullName", func(m *TModelStructMenu) any {
+		first, _ := m.FieldValue("FirstName")
+		last, _ := m.FieldValue("LastName")
+		return first.(string) + " " + last.(string)
+	})
+	if err != nil {
+		t.Fatalf("RegisterComputed: %v", err)
+	}
+	return m
+}
+
+func TestComputedFieldFollowsInputs(t *testing.T) {
+	obj := nameForm{FirstName: "Jane", LastName: "Doe"}
+	m := newNameMenu(t, &obj)
+	if v, _ := m.FieldValue("FullName"); v != "Jane Doe" {
+		t.Errorf("FullName = %q, want %q", v, "Jane Doe")
+	}
+
+	m = SendKeys(m, "down", "enter", "ctrl+u", "R", "o", "e", "enter")
+	if v, _ := m.FieldValue("FullName"); v != "Jane Roe" {
+		t.Errorf("FullName = %q after editing LastName, want %q", v, "Jane Roe")
+	}
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.FullName != "Jane Roe" {
+		t.Errorf("ParseStruct wrote FullName %q, want %q", obj.FullName, "Jane Roe")
+	}
+}
+
+func TestComputedFieldIsReadOnly(t *testing.T) {
+	obj := nameForm{FirstName: "Jane", LastName: "Doe"}
+	m := newNameMenu(t, &obj)
+	m = SendKeys(m, "down", "down", "enter", "x", "enter")
+	if m.cursor != 2 || m.isEditingValue {
+		t.Errorf("cursor on %d, editing %v; want the computed field focused but not edited", m.cursor, m.isEditingValue)
+	}
+	if v, _ := m.FieldValue("FullName"); v != "Jane Doe" {
+		t.Errorf("FullName = %q, want it left as computed", v)
+	}
+}
+
+func TestRegisterComputedUnknownField(t *testing.T) {
+	var obj nameForm
+	m := newTestMenu(t, &obj)
+	if err := m.RegisterComputed("Nickname", func(*TModelStructMenu) any { return "" }); err == nil {
+		t.Error("RegisterComputed accepted a field the menu doesn't expose")
+	}
+}
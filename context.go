@@ -0,0 +1,42 @@
+package gostructui
+
+import (
+	"context"
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CancelMsg quits the menu without saving, as if the user had
+// cancelled it, with QuitWithCancel set. A parent model may send
+// it to tear the menu down, as when a timeout elapses elsewhere.
+// A menu already saved is left as it is.
+type CancelMsg struct{}
+
+// WithContext ties the menu to ctx. Once ctx is done, the menu is
+// sent a CancelMsg and quits as if the user had cancelled it, so
+// that QuitWithCancel tells it apart from a save. The context is
+// watched by the command returned from Init, which a parent model
+// embedding the menu must run for this to take effect, until the
+// menu is saved or cancelled.
+func WithContext(ctx context.Context) MenuOption {
+	return func(m *TModelStructMenu) {
+		m.ctx, m.unwatch = context.WithCancelCause(ctx)
+	}
+}
+
+// errMenuQuit stops the watch on the context
+// of a menu once the menu has quit.
+var errMenuQuit = errors.New("menu quit")
+
+// watchContext returns a command that waits for ctx to be done,
+// then sends a CancelMsg, unless the menu quit in the meantime.
+func watchContext(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		<-ctx.Done()
+		if errors.Is(context.Cause(ctx), errMenuQuit) {
+			return nil
+		}
+		return CancelMsg{}
+	}
+}
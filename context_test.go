@@ -0,0 +1,35 @@
+package gostructui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDoneContextCancelsMenu(t *testing.T) {
+	obj := struct{ Name string }{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := newTestMenu(t, &obj, WithContext(ctx)).Init()
+	cancel()
+	if msg := cmd(); msg != (CancelMsg{}) {
+		t.Errorf("watch on a done context sent %#v, want a CancelMsg", msg)
+	}
+}
+
+func TestContextUnwatchedOnceMenuQuits(t *testing.T) {
+	obj := struct{ Name string }{}
+	m := newTestMenu(t, &obj, WithContext(context.Background()))
+	sent := make(chan tea.Msg)
+	go func() { sent <- m.Init()() }()
+	SendKeys(m, "q")
+	select {
+	case msg := <-sent:
+		if msg != nil {
+			t.Errorf("watch sent %#v after the menu quit", msg)
+		}
+	case <-time.After(time.Second):
+		t.Error("context still watched after the menu quit")
+	}
+}
@@ -0,0 +1,50 @@
+package gostructui
+
+import (
+	"strings"
+	"testing"
+)
+
+type defaultForm struct {
+	Host  string `smdefault:"localhost"`
+	Port  int    `smdefault:"8080"`
+	Debug bool   `smdefault:"true"`
+}
+
+func TestDefaultPrefillsZeroValues(t *testing.T) {
+	var obj defaultForm
+	m := newTestMenu(t, &obj)
+	if m.IsDirty() {
+		t.Error("IsDirty() = true for a form left as it was shown")
+	}
+	if view := m.View(); strings.Contains(view, "*") {
+		t.Errorf("View marks untouched defaults as changed:\n%s", view)
+	}
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if want := (defaultForm{"localhost", 8080, true}); obj != want {
+		t.Errorf("ParseStruct wrote %+v, want %+v", obj, want)
+	}
+}
+
+func TestNonZeroValueWinsOverDefault(t *testing.T) {
+	obj := defaultForm{Host: "example.com", Port: 443}
+	m := newTestMenu(t, &obj)
+	var out defaultForm
+	if err := m.ParseStruct(&out); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if want := (defaultForm{"example.com", 443, true}); out != want {
+		t.Errorf("ParseStruct wrote %+v, want %+v", out, want)
+	}
+}
+
+func TestInvalidDefaultIsAnError(t *testing.T) {
+	obj := struct {
+		Port int `smdefault:"eighty"`
+	}{}
+	if _, err := InitialTModelStructMenu(&obj, nil, false, nil); err == nil {
+		t.Error("InitialTModelStructMenu accepted an smdefault tag that doesn't parse")
+	}
+}
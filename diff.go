@@ -0,0 +1,64 @@
+package gostructui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// IsDirty reports whether any field value differs
+// from the value it held when the menu was created.
+// Callers may use it to skip writing back an unchanged struct.
+func (m TModelStructMenu) IsDirty() bool {
+	for i := range m.menuFields {
+		if m.getFieldAtIndex(i).isDirty() {
+			return true
+		}
+	}
+	return false
+}
+
+// OriginalValue returns the value the exposed struct field with
+// the given name held when the menu was created, as FieldValue
+// returns the current one. The returned bool is false if no such
+// field is exposed by the menu. Lists and maps are returned as
+// copies, so the original values cannot be changed through the result.
+func (m TModelStructMenu) OriginalValue(name string) (any, bool) {
+	f := m.getFieldByName(name)
+	if f == nil {
+		return nil, false
+	}
+	switch orig := f.orig.(type) {
+	case []string:
+		return slices.Clone(orig), true
+	case map[string]string:
+		return maps.Clone(orig), true
+	}
+	return f.orig, true
+}
+
+// DiffView renders the original and current values of each
+// changed field as "Name: old → new", one per line, with masked
+// fields kept masked. Unchanged fields are left out, so an empty
+// string means nothing has changed. It is what users review before
+// saving when Settings.ShowDiffOnSave is set, and may be printed by
+// callers as a record of what was edited.
+func (m TModelStructMenu) DiffView() string {
+	var s string
+	for i := range m.menuFields {
+		f := m.getFieldAtIndex(i)
+		if f.isDirty() {
+			old, cur := f.origText(), f.text()
+			s += fmt.Sprintf("  %s: %s → %s\n", f.getFieldName(), f.maskString(old), f.maskString(cur))
+		}
+	}
+	return s
+}
+
+// origText returns the value the field held when the menu was
+// created as text, formatted as text formats the current value.
+func (f *menuField) origText() string {
+	orig := *f
+	orig.setValue(f.orig)
+	return orig.text()
+}
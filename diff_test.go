@@ -0,0 +1,87 @@
+package gostructui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOriginalValueIsACopy(t *testing.T) {
+	obj := struct {
+		Tags   []string
+		Labels map[string]string
+	}{
+		Tags:   []string{"a"},
+		Labels: map[string]string{"env": "prod"},
+	}
+	m := newTestMenu(t, &obj)
+
+	tags, _ := m.OriginalValue("Tags")
+	tags.([]string)[0] = "changed"
+	labels, _ := m.OriginalValue("Labels")
+	labels.(map[string]string)["env"] = "changed"
+
+	if tags, _ := m.OriginalValue("Tags"); !slices.Equal(tags.([]string), []string{"a"}) {
+		t.Errorf("OriginalValue(Tags) = %q after changing a copy", tags)
+	}
+	if labels, _ := m.OriginalValue("Labels"); !maps.Equal(labels.(map[string]string), map[string]string{"env": "prod"}) {
+		t.Errorf("OriginalValue(Labels) = %q after changing a copy", labels)
+	}
+	if m.IsDirty() {
+		t.Error("IsDirty() = true after changing copies of original values")
+	}
+}
+
+func TestParseChangedIntoWritesOnlyChangedFields(t *testing.T) {
+	obj := struct {
+		Name  string
+		Email string
+		Port  int
+	}{Name: "Jane", Email: "jane@example.com", Port: 80}
+	m := SendKeys(newTestMenu(t, &obj), "down", "down", "enter", "8", "1", "enter")
+
+	// changes made to the struct elsewhere meanwhile are kept
+	obj.Name, obj.Email = "John", "john@example.com"
+	if err := m.ParseChangedInto(&obj); err != nil {
+		t.Fatalf("ParseChangedInto: %v", err)
+	}
+	if obj.Name != "John" || obj.Email != "john@example.com" || obj.Port != 81 {
+		t.Errorf("ParseChangedInto wrote %+v", obj)
+	}
+}
+
+func TestSaveShowsDiffOfChangedFields(t *testing.T) {
+	obj := struct {
+		Name     string
+		Port     int
+		Password string `smmask:"true"`
+		Debug    bool
+	}{Name: "Jane", Port: 80, Password: "old"}
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.ShowDiffOnSave = true
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = SendKeys(m, "down", "enter", "8", "1", "enter", "enter", "ctrl+u", "n", "e", "w", "enter", "s")
+	if !m.confirmingSave {
+		t.Fatal("save didn't ask to confirm the changes")
+	}
+	if got, want := m.DiffView(), "  Port: 80 → 81\n  Password: *** → ***\n"; got != want {
+		t.Errorf("DiffView() = %q, want %q", got, want)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Port: 80 → 81") || strings.Contains(view, "Name:") || strings.Contains(view, "Debug:") {
+		t.Errorf("View doesn't list only the changed fields:\n%s", view)
+	}
+
+	// going back to edit keeps the changes, and confirming saves them
+	if m = SendKeys(m, "n"); m.confirmingSave || m.saved {
+		t.Error("n didn't go back to editing")
+	}
+	if m = SendKeys(m, "s", "y"); !m.saved {
+		t.Error("y didn't save")
+	}
+}
@@ -0,0 +1,34 @@
+package gostructui
+
+import "strings"
+
+// defaultDigitSeparator is the DigitSeparator used when none is set.
+const defaultDigitSeparator = ","
+
+// digitSeparator returns the configured DigitSeparator,
+// or the default if it was left unset.
+func (m *MenuSettings) digitSeparator() string {
+	if m.DigitSeparator == "" {
+		return defaultDigitSeparator
+	}
+	return m.DigitSeparator
+}
+
+// groupDigits inserts sep between every group of three digits
+// of the formatted integer s, counting from the right, as in
+// "1,200,000". A leading sign is kept in front.
+func groupDigits(s, sep string) string {
+	sign, digits := "", s
+	if strings.HasPrefix(s, "-") {
+		sign, digits = "-", s[1:]
+	}
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package gostructui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// durationChars holds the characters that
+// can make up a formatted duration.
+const durationChars = "0123456789.+-nsuµmh"
+
+// parseDuration parses s as a duration, such as "1m30s".
+func parseDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (expected a form like 1m30s)", s)
+	}
+	return d, nil
+}
+
+// handleDurationKey types a character into the buffer of a
+// duration field. Characters that can't be part of a
+// duration are ignored.
+func (f *menuField) handleDurationKey(key string) {
+	if len([]rune(key)) == 1 && strings.Contains(durationChars, key) {
+		f.editBuf += key
+	}
+}
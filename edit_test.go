@@ -0,0 +1,87 @@
+package gostructui
+
+import "testing"
+
+func TestTypingSpaceIntoString(t *testing.T) {
+	obj := struct {
+		Name  string
+		Admin bool
+	}{}
+	keys := []string{"enter", "h", "e", "l", "l", "o", " ", "w", "o", "r", "l", "d", "enter"}
+	m := SendKeys(newTestMenu(t, &obj), keys...)
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Name != "hello world" {
+		t.Errorf("Name = %q, want %q", obj.Name, "hello world")
+	}
+	if obj.Admin {
+		t.Error("a space typed into a string toggled a bool")
+	}
+}
+
+func TestDeleteKey(t *testing.T) {
+	for _, tt := range []struct {
+		from string
+		keys []string
+		want string
+	}{
+		{"abc", []string{"enter", "left", "left", "delete", "enter"}, "ac"},
+		{"abc", []string{"enter", "left", "left", "left", "delete", "delete", "enter"}, "c"},
+		{"abc", []string{"enter", "delete", "enter"}, "abc"},
+		{"", []string{"enter", "delete", "enter"}, ""},
+		{"", []string{"enter", "delete", "x", "enter"}, "x"},
+	} {
+		obj := struct{ Name string }{Name: tt.from}
+		if got := nameAfter(t, newTestMenu(t, &obj), tt.keys...); got != tt.want {
+			t.Errorf("keys %q on %q gave %q, want %q", tt.keys, tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteWord(t *testing.T) {
+	for _, tt := range []struct {
+		buf   string
+		caret int
+		want  string
+		at    int
+	}{
+		{"hello world", 11, "hello ", 6},
+		{"hello world  ", 13, "hello ", 6},
+		{"hello world", 5, " world", 0},
+		{"hello world", 8, "hello rld", 6},
+		{"hello", 0, "hello", 0},
+		{"", 0, "", 0},
+		{"  ", 2, "", 0},
+		{"one\ttwo", 7, "one\t", 4},
+		{"café au lait", 7, "café  lait", 5},
+	} {
+		f := menuField{kind: FieldString, editBuf: tt.buf, caret: tt.caret}
+		f.deleteWord()
+		if f.editBuf != tt.want || f.caret != tt.at {
+			t.Errorf("deleteWord() on %q at %d left %q at %d, want %q at %d", tt.buf, tt.caret, f.editBuf, f.caret, tt.want, tt.at)
+		}
+	}
+}
+
+func TestClearAndDeleteWordKeys(t *testing.T) {
+	obj := struct{ Name string }{Name: "hello big world"}
+	if got := nameAfter(t, newTestMenu(t, &obj), "enter", "ctrl+w", "enter"); got != "hello big " {
+		t.Errorf("ctrl+w gave %q, want %q", got, "hello big ")
+	}
+	if got := nameAfter(t, newTestMenu(t, &obj), "enter", "ctrl+u", "x", "enter"); got != "x" {
+		t.Errorf("ctrl+u gave %q, want %q", got, "x")
+	}
+
+	// the bindings can be disabled through the key map
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.Keys.DeleteWord = []string{}
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := nameAfter(t, m, "enter", "ctrl+w", "enter"); got != "hello big world" {
+		t.Errorf("disabled ctrl+w gave %q", got)
+	}
+}
@@ -0,0 +1,16 @@
+package gostructui
+
+// showsEmptyMarker reports whether Settings.EmptyMarker is shown in
+// place of the value of the field, which it is for editable numeric
+// and bool fields still holding the zero value they started with,
+// untouched by users.
+func (f *menuField) showsEmptyMarker(settings *MenuSettings) bool {
+	if settings.EmptyMarker == "" || f.touched || f.isReadOnly() || f.isDirty() || f.isNil {
+		return false
+	}
+	switch f.kind {
+	case FieldInt, FieldUint, FieldDuration, FieldBool:
+		return isEmptyValue(f.value())
+	}
+	return false
+}
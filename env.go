@@ -0,0 +1,50 @@
+package gostructui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadValuesEnv prefills the menu from environment variables, as
+// for CLI tools taking some of their settings from the environment
+// while still letting users review them. Each field is read from
+// the variable named by prefix, an underscore and the name of the
+// struct field in upper case, with the dots of nested fields also
+// turned into underscores (e.g. APP_ADDRESS_CITY for Address.City
+// under the prefix APP). Without a prefix, the name stands alone.
+// Lists are read as comma-separated entries, and dates in the
+// layout of their field. Fields without a variable are left as
+// they are, as are read-only ones. Values that don't fit their
+// fields are recorded in Warnings rather than failing the load.
+func (m *TModelStructMenu) LoadValuesEnv(prefix string) {
+	for i := range m.menuFields {
+		f := m.getFieldAtIndex(i)
+		if f.isReadOnly() {
+			continue
+		}
+		name := envName(prefix, f.name)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := f.setText(value); err != nil {
+			m.warnings = append(m.warnings, fmt.Sprintf("environment variable '%s' left unused: %v", name, err))
+		}
+	}
+	if err := m.recompute(); err != nil {
+		m.warnings = append(m.warnings, err.Error())
+	}
+	m.checkValidity()
+}
+
+// envName returns the name of the environment
+// variable a field is read from, as described
+// by LoadValuesEnv.
+func envName(prefix, fieldName string) string {
+	name := strings.ToUpper(strings.ReplaceAll(fieldName, ".", "_"))
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
@@ -0,0 +1,47 @@
+package gostructui
+
+import (
+	"slices"
+	"testing"
+)
+
+type envForm struct {
+	Name  string
+	Port  int
+	Tags  []string
+	Debug bool
+}
+
+func TestLoadValuesEnv(t *testing.T) {
+	t.Setenv("APP_NAME", "api")
+	t.Setenv("APP_PORT", "8080")
+	t.Setenv("APP_TAGS", "a, b,,c")
+	t.Setenv("APP_DEBUG", "maybe")
+
+	var obj envForm
+	m := newTestMenu(t, &obj)
+	m.LoadValuesEnv("APP")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Name != "api" || obj.Port != 8080 || !slices.Equal(obj.Tags, []string{"a", "b", "c"}) || obj.Debug {
+		t.Errorf("LoadValuesEnv loaded %+v", obj)
+	}
+	if len(m.Warnings()) != 1 {
+		t.Errorf("Warnings() = %q, want one for APP_DEBUG", m.Warnings())
+	}
+}
+
+func TestLoadValuesEnvEmptyList(t *testing.T) {
+	t.Setenv("APP_TAGS", "")
+
+	obj := envForm{Tags: []string{"old"}}
+	m := newTestMenu(t, &obj)
+	m.LoadValuesEnv("APP")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Tags != nil {
+		t.Errorf("Tags = %q, want nil", obj.Tags)
+	}
+}
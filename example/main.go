@@ -39,23 +39,22 @@ func main() {
 	// STEP 4: Initialize a menu!
 	// Provide a pointer to your struct, blacklisted or
 	// whitelisted fields, and any custom settings.
-	configEditMenu, err := gostructui.InitialTModelStructMenu(&newApplication, []string{"BlacklistedField"}, true, customMenuSettings)
+	configEditMenu, err := gostructui.NewMenu(&newApplication, []string{"BlacklistedField"}, true, customMenuSettings)
 	if err != nil {
 		log.Fatal("Trouble generating the application.")
 	}
 	// STEP 5: Use the menu---a bubbletea model---with the bubbletea package!
-	// Here, we capture the result (our struct with user-entered values)
-	// as the tea.Model variable "entry".
+	// The menu keeps track of the user's input as the program runs,
+	// so it can be read back from once the program returns.
 	p := tea.NewProgram(configEditMenu)
-	if entry, err := p.Run(); err != nil {
+	if _, err := p.Run(); err != nil {
 		log.Fatal("Trouble generating the application.")
 	} else {
-		if entry.(gostructui.TModelStructMenu).QuitWithCancel {
+		if configEditMenu.QuitWithCancel {
 			fmt.Printf("Canceled application.\n")
 			os.Exit(0)
 		} else {
-			err = entry.(gostructui.TModelStructMenu).ParseStruct(&newApplication)
-			if err != nil {
+			if _, err := configEditMenu.Result(); err != nil {
 				log.Fatal("Trouble generating the application.")
 			}
 
@@ -0,0 +1,123 @@
+package gostructui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// exportPath returns the keys under which the field is exported
+// for the given format, one for each nested struct the field lies
+// in and a last one for the field itself. Each is read from the
+// struct tag of the same name as the format (e.g. `yaml:"city"`)
+// at its level, falling back to the name of the struct field as
+// the encoding library reads it back: lowercased for YAML, and as
+// is for TOML. The returned bool is false if a tag at any level
+// excludes the field with "-".
+func (f *menuField) exportPath(format string) ([]string, bool) {
+	names := strings.Split(f.name, ".")
+	path := make([]string, len(names))
+	for i, name := range names {
+		tag := f.tag
+		if i < len(f.parents) {
+			tag = f.parents[i]
+		}
+		key, _, _ := strings.Cut(tag.Get(format), ",")
+		switch key {
+		case "-":
+			return nil, false
+		case "":
+			key = name
+			if format == "yaml" {
+				key = strings.ToLower(name)
+			}
+		}
+		path[i] = key
+	}
+	return path, true
+}
+
+// exportValues collects the current field values into a map,
+// nesting the values of fields of nested structs in maps of their
+// own along the path given by exportPath for the given format.
+// Values take the form ValuesJSON writes them in. Fields with an
+// smmask tag are left out, so that secrets don't end up in the
+// document, as are unset pointer fields for formats without null.
+// An error is returned if two fields would be exported under the
+// same key, rather than have one of them lost.
+func (m TModelStructMenu) exportValues(format string) (map[string]any, error) {
+	values := make(map[string]any, len(m.menuFields))
+	for i := range m.menuFields {
+		f := m.getFieldAtIndex(i)
+		path, ok := f.exportPath(format)
+		if !ok || f.mask {
+			continue
+		}
+		v := f.encodedValue()
+		if v == nil && format == "toml" {
+			continue
+		}
+		if err := putPath(values, path, v); err != nil {
+			return nil, fmt.Errorf("cannot export field '%s' to %s: %w", f.name, strings.ToUpper(format), err)
+		}
+	}
+	return values, nil
+}
+
+// putPath sets v in values under the given path of keys, creating
+// the maps leading up to it as needed. It refuses to replace any
+// value already set along the way.
+func putPath(values map[string]any, path []string, v any) error {
+	for i, key := range path[:len(path)-1] {
+		existing, ok := values[key]
+		if !ok {
+			existing = map[string]any{}
+			values[key] = existing
+		}
+		nested, ok := existing.(map[string]any)
+		if !ok {
+			return fmt.Errorf("key '%s' is taken by another field", strings.Join(path[:i+1], "."))
+		}
+		values = nested
+	}
+	key := path[len(path)-1]
+	if _, ok := values[key]; ok {
+		return fmt.Errorf("key '%s' is taken by another field", strings.Join(path, "."))
+	}
+	values[key] = v
+	return nil
+}
+
+// ToYAML marshals the current field values into a YAML document.
+// Keys are taken from yaml struct tags where present, and
+// otherwise from the lowercased names of the struct fields, as
+// yaml.v3 reads them back, with fields of nested structs nested
+// under theirs. Unset pointer fields are
+// written as null.
+func (m TModelStructMenu) ToYAML() ([]byte, error) {
+	values, err := m.exportValues("yaml")
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(values)
+}
+
+// ToTOML marshals the current field values into a TOML document.
+// Keys are taken from toml struct tags where present, and
+// otherwise from the names of the struct fields, with fields of
+// nested structs in tables of their own. TOML has no null, so
+// unset pointer fields are left out.
+func (m TModelStructMenu) ToTOML() ([]byte, error) {
+	values, err := m.exportValues("toml")
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(values); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,155 @@
+package gostructui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+type exportConfig struct {
+	Name     string        `yaml:"name" toml:"name"`
+	Port     int           `yaml:"port" toml:"port"`
+	Debug    bool          `yaml:"debug" toml:"debug"`
+	Timeout  time.Duration `yaml:"timeout" toml:"timeout"`
+	Tags     []string      `yaml:"tags" toml:"tags"`
+	Password string        `yaml:"password" toml:"password" smmask:"true"`
+	Retries  *int          `yaml:"retries" toml:"retries"`
+	Internal string        `yaml:"-" toml:"-"`
+}
+
+func newExportMenu(t *testing.T) TModelStructMenu {
+	t.Helper()
+	return newTestMenu(t, &exportConfig{
+		Name:     "api",
+		Port:     8080,
+		Debug:    true,
+		Timeout:  90 * time.Second,
+		Tags:     []string{"a", "b"},
+		Password: "hunter2",
+		Internal: "hidden",
+	})
+}
+
+func TestToYAML(t *testing.T) {
+	doc, err := newExportMenu(t).ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML: %v", err)
+	}
+	var got map[string]any
+	if err := yaml.Unmarshal(doc, &got); err != nil {
+		t.Fatalf("ToYAML wrote invalid YAML: %v\n%s", err, doc)
+	}
+	want := map[string]any{
+		"name":    "api",
+		"port":    8080,
+		"debug":   true,
+		"timeout": "1m30s",
+		"tags":    []any{"a", "b"},
+		"retries": nil,
+	}
+	if len(got) != len(want) {
+		t.Errorf("ToYAML wrote keys %v, want %v", got, want)
+	}
+	for key, v := range want {
+		if g, ok := got[key]; !ok || !sameValue(g, v) {
+			t.Errorf("ToYAML wrote %s: %v, want %v", key, g, v)
+		}
+	}
+	if strings.Contains(string(doc), "hunter2") {
+		t.Errorf("ToYAML leaked a masked field:\n%s", doc)
+	}
+}
+
+func TestToTOML(t *testing.T) {
+	doc, err := newExportMenu(t).ToTOML()
+	if err != nil {
+		t.Fatalf("ToTOML: %v", err)
+	}
+	var got map[string]any
+	if _, err := toml.Decode(string(doc), &got); err != nil {
+		t.Fatalf("ToTOML wrote invalid TOML: %v\n%s", err, doc)
+	}
+	want := map[string]any{
+		"name":    "api",
+		"port":    int64(8080),
+		"debug":   true,
+		"timeout": "1m30s",
+		"tags":    []any{"a", "b"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("ToTOML wrote keys %v, want %v", got, want)
+	}
+	for key, v := range want {
+		if g, ok := got[key]; !ok || !sameValue(g, v) {
+			t.Errorf("ToTOML wrote %s: %v, want %v", key, g, v)
+		}
+	}
+	if strings.Contains(string(doc), "hunter2") {
+		t.Errorf("ToTOML leaked a masked field:\n%s", doc)
+	}
+}
+
+type exportAddress struct {
+	City string `yaml:"city" toml:"city"`
+	Zip  string
+}
+
+type exportPerson struct {
+	Name string        `yaml:"name" toml:"name"`
+	Home exportAddress `yaml:"home" toml:"home"`
+	Work exportAddress
+}
+
+func TestExportNestsFieldsOfNestedStructs(t *testing.T) {
+	obj := exportPerson{
+		Name: "Jane",
+		Home: exportAddress{City: "Berlin", Zip: "10115"},
+		Work: exportAddress{City: "Paris", Zip: "75001"},
+	}
+	m := newTestMenu(t, &obj)
+
+	doc, err := m.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML: %v", err)
+	}
+	var fromYAML exportPerson
+	if err := yaml.Unmarshal(doc, &fromYAML); err != nil {
+		t.Fatalf("ToYAML wrote invalid YAML: %v\n%s", err, doc)
+	}
+	if fromYAML != obj {
+		t.Errorf("ToYAML wrote a document reading back as %+v, want %+v:\n%s", fromYAML, obj, doc)
+	}
+
+	doc, err = m.ToTOML()
+	if err != nil {
+		t.Fatalf("ToTOML: %v", err)
+	}
+	var fromTOML exportPerson
+	if _, err := toml.Decode(string(doc), &fromTOML); err != nil {
+		t.Fatalf("ToTOML wrote invalid TOML: %v\n%s", err, doc)
+	}
+	if fromTOML != obj {
+		t.Errorf("ToTOML wrote a document reading back as %+v, want %+v:\n%s", fromTOML, obj, doc)
+	}
+}
+
+func TestExportRefusesDuplicateKeys(t *testing.T) {
+	obj := struct {
+		Home exportAddress `yaml:"addr"`
+		Work exportAddress `yaml:"addr"`
+	}{}
+	if doc, err := newTestMenu(t, &obj).ToYAML(); err == nil {
+		t.Errorf("ToYAML exported two fields under the same key:\n%s", doc)
+	}
+
+	clash := struct {
+		Home exportAddress `toml:"home"`
+		Addr string        `toml:"home"`
+	}{}
+	if doc, err := newTestMenu(t, &clash).ToTOML(); err == nil {
+		t.Errorf("ToTOML exported a value over a table:\n%s", doc)
+	}
+}
@@ -0,0 +1,65 @@
+package gostructui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// isHidden reports whether the field at index i is left out
+// of the menu, either for its smshowif condition not being met,
+// or by the filter, which keeps only the fields whose names
+// contain the filter, regardless of case. There being no field
+// at index i, it counts as hidden.
+func (m *TModelStructMenu) isHidden(i int) bool {
+	if i < 0 || i >= len(m.menuFields) {
+		return true
+	}
+	if !m.isShown(m.getFieldAtIndex(i)) {
+		return true
+	}
+	if m.filter == "" {
+		return false
+	}
+	name := strings.ToLower(m.getFieldAtIndex(i).getFieldName())
+	return !strings.Contains(name, strings.ToLower(m.filter))
+}
+
+// handleFilterKey applies a key pressed while typing a filter.
+// Enter keeps the filter and returns to navigating the fields
+// left by it, while escape clears the filter altogether.
+func (m *TModelStructMenu) handleFilterKey(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+		// a filter leaving no fields to navigate is of no use
+		if m.isHidden(m.cursor) {
+			m.filter = ""
+		}
+		return
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter = ""
+		return
+	case tea.KeyBackspace:
+		if runes := []rune(m.filter); len(runes) > 0 {
+			m.filter = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		m.filter += string(msg.Runes)
+	case tea.KeySpace:
+		m.filter += " "
+	default:
+		return
+	}
+
+	// keep the cursor on a field the filter leaves, if any
+	if m.isHidden(m.cursor) {
+		for i := range m.menuFields {
+			if !m.isHidden(i) {
+				m.cursor = i
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package gostructui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultFooterText is the FooterText set by Init.
+const DefaultFooterText = "Press {save} to save and quit.\nPress {cancel} to quit without saving.\nPress {help} for help."
+
+// footerKeys pairs the placeholders of FooterText with the
+// bindings of the keys they stand for, in a fixed order so that
+// the footer renders the same way every time.
+func footerKeys(keys KeyMap) []struct {
+	placeholder string
+	binding     []string
+} {
+	return []struct {
+		placeholder string
+		binding     []string
+	}{
+		{"{save}", keys.Save},
+		{"{cancel}", keys.Cancel},
+		{"{help}", keys.Help},
+		{"{edit}", keys.ToggleEdit},
+		{"{filter}", keys.Filter},
+		{"{back}", keys.Back},
+	}
+}
+
+// footerHints renders the lines of Settings.FooterText, with each
+// placeholder replaced by the first key bound to its action. Lines
+// naming an action that is disabled are left out. The line naming
+// the save key also carries the count of fields failing validation,
+// and gives way to a notice while saving is blocked.
+func (m TModelStructMenu) footerHints() string {
+	keys := footerKeys(m.Settings.Keys.withDefaults())
+	var s string
+lines:
+	for _, line := range strings.Split(m.Settings.FooterText, "\n") {
+		if line == "" {
+			continue
+		}
+		isSave := strings.Contains(line, "{save}")
+		for _, key := range keys {
+			if !strings.Contains(line, key.placeholder) {
+				continue
+			}
+			if len(key.binding) == 0 {
+				continue lines
+			}
+			line = strings.ReplaceAll(line, key.placeholder, keyNames(key.binding)[0])
+		}
+
+		switch n := len(m.validationErrs); {
+		case isSave && m.saveBlocked():
+			s += render(m.Settings.Styles.Footer, "Saving is blocked until every field is valid.") + "\n"
+			continue
+		case isSave && n == 1:
+			line = render(m.Settings.Styles.Footer, line) + " " + styleOr(m.Settings.Styles.Error, errorStyle).Render("(1 error)")
+		case isSave && n > 1:
+			line = render(m.Settings.Styles.Footer, line) + " " + styleOr(m.Settings.Styles.Error, errorStyle).Render(fmt.Sprintf("(%d errors)", n))
+		default:
+			line = render(m.Settings.Styles.Footer, line)
+		}
+		s += line + "\n"
+	}
+	return s
+}
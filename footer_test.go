@@ -0,0 +1,28 @@
+package gostructui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProgressCountsFieldsShown(t *testing.T) {
+	obj := struct {
+		Name     string
+		HasPet   bool
+		PetName  string `smshowif:"HasPet"`
+		Email    string
+		Nickname string
+	}{}
+	m := newTestMenu(t, &obj)
+	if view := m.View(); !strings.Contains(view, "Field 1 / 4") {
+		t.Errorf("View doesn't count the fields shown:\n%s", view)
+	}
+	if m = SendKeys(m, "down", " "); !strings.Contains(m.View(), "Field 3 / 5") {
+		t.Errorf("View doesn't count a field shown by smshowif:\n%s", m.View())
+	}
+
+	m = SendKeys(newTestMenu(t, &obj), "/", "n", "a", "m", "e", "enter", "down")
+	if view := m.View(); !strings.Contains(view, "Field 2 / 2") {
+		t.Errorf("View doesn't count only the fields the filter leaves:\n%s", view)
+	}
+}
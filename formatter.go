@@ -0,0 +1,49 @@
+package gostructui
+
+import (
+	"reflect"
+)
+
+// FieldFormatter is implemented by types that show and read
+// their own values as text. Fields of such types are exposed
+// in the menu as their Format text, typed into like strings,
+// and written back through Parse, which is called on a copy
+// of the value and should report text it can't accept.
+// Parse will usually have a pointer receiver, so that it
+// can set the value it is called on.
+type FieldFormatter interface {
+	Format() string
+	Parse(s string) error
+}
+
+var formatterType = reflect.TypeOf((*FieldFormatter)(nil)).Elem()
+
+// isFormatterType reports whether values of type t
+// format and parse themselves as a FieldFormatter.
+func isFormatterType(t reflect.Type) bool {
+	return t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface &&
+		reflect.PointerTo(t).Implements(formatterType)
+}
+
+// format returns the text of the value of a formatted field.
+func (f *menuField) format() string {
+	return f.custom.Interface().(FieldFormatter).Format()
+}
+
+// parseCustom parses s into a new value of the type
+// of a formatted field, leaving the field untouched.
+func (f *menuField) parseCustom(s string) (reflect.Value, error) {
+	p := reflect.New(f.custom.Type().Elem())
+	if err := p.Interface().(FieldFormatter).Parse(s); err != nil {
+		return reflect.Value{}, err
+	}
+	return p, nil
+}
+
+// handleCustomKey types a character into the
+// buffer of a formatted field.
+func (f *menuField) handleCustomKey(key string) {
+	if len([]rune(key)) == 1 {
+		f.editBuf += key
+	}
+}
@@ -0,0 +1,58 @@
+package gostructui
+
+import (
+	"strings"
+	"testing"
+)
+
+type groupedForm struct {
+	Name  string
+	Email string `smgroup:"Contact"`
+	Age   int
+	Phone string `smgroup:"Contact"`
+}
+
+func TestGroupsAreShownTogether(t *testing.T) {
+	var obj groupedForm
+	m := newTestMenu(t, &obj)
+	view := m.View()
+	if i, j, k := strings.Index(view, "Age"), strings.Index(view, "Email"), strings.Index(view, "Phone"); !(i < j && j < k) {
+		t.Errorf("View doesn't list the Contact group together:\n%s", view)
+	}
+	if !strings.Contains(view, "Contact") {
+		t.Errorf("View doesn't head the Contact group:\n%s", view)
+	}
+}
+
+func TestGroupsKeepFieldOrder(t *testing.T) {
+	var obj groupedForm
+	m := newTestMenu(t, &obj)
+	var names []string
+	for _, f := range m.menuFields {
+		names = append(names, f.name)
+	}
+	if got, want := strings.Join(names, ","), "Name,Email,Age,Phone"; got != want {
+		t.Errorf("fields are in order %s, want %s", got, want)
+	}
+}
+
+func TestNavigationFollowsGroups(t *testing.T) {
+	var obj groupedForm
+	m := newTestMenu(t, &obj)
+	var visited []string
+	for range 4 {
+		visited = append(visited, m.getFieldUnderCursor().name)
+		m = SendKeys(m, "down")
+	}
+	if got, want := strings.Join(visited, ","), "Name,Age,Email,Phone"; got != want {
+		t.Errorf("moving down visited %s, want %s", got, want)
+	}
+
+	m = SendKeys(newTestMenu(t, &obj), "down", "down", "enter", "5", "enter")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Email != "5" || obj.Phone != "" {
+		t.Errorf("editing the third field shown wrote %+v", obj)
+	}
+}
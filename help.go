@@ -0,0 +1,75 @@
+package gostructui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// helpView renders the keys bound to each action of the menu,
+// one action per line. Disabled actions are left out.
+func (m TModelStructMenu) helpView() string {
+	keys := m.Settings.Keys.withDefaults()
+	// moving between columns only matters if there may be several
+	if m.Settings.Columns <= 1 {
+		keys.PrevColumn, keys.NextColumn = nil, nil
+	}
+	bindings := []struct {
+		keys []string
+		desc string
+	}{
+		{keys.Up, "move to the field above"},
+		{keys.Down, "move to the field below"},
+		{keys.PrevColumn, "move to the column on the left"},
+		{keys.NextColumn, "move to the column on the right"},
+		{keys.PrevField, "tab back to the previous editable field"},
+		{keys.NextField, "tab forward to the next editable field"},
+		{keys.First, "jump to the first field"},
+		{keys.Last, "jump to the last field"},
+		{keys.PageUp, "move up a page"},
+		{keys.PageDown, "move down a page"},
+		{keys.ToggleEdit, "edit a field, or commit the edit"},
+		{keys.Decrease, "step a number or option down"},
+		{keys.Increase, "step a number or option up"},
+		{keys.ToggleBool, "flip a checkbox"},
+		{keys.ClearField, "clear the value being typed"},
+		{keys.DeleteWord, "delete the word before the caret"},
+		{keys.ResetField, "restore the original value of a field"},
+		{keys.ResetAll, "restore the original values of all fields"},
+		{keys.Copy, "copy the value of a field"},
+		{keys.Filter, "filter the fields by name"},
+		{keys.Save, "save and quit"},
+		{keys.Cancel, "quit without saving"},
+		{keys.Help, "show or hide this help"},
+	}
+
+	var rows [][2]string
+	width := 0
+	for _, b := range bindings {
+		if len(b.keys) == 0 {
+			continue
+		}
+		row := [2]string{strings.Join(keyNames(b.keys), "/"), b.desc}
+		width = max(width, len(row[0]))
+		rows = append(rows, row)
+	}
+
+	s := "Keybindings\n\n"
+	for _, row := range rows {
+		s += fmt.Sprintf("  %s  %s\n", padRight(row[0], width), row[1])
+	}
+	s += "\n" + render(m.Settings.Styles.Footer, "Press "+strings.Join(append(keyNames(keys.Help), "esc"), " or ")+" to close this help.") + "\n"
+	return s
+}
+
+// keyNames returns the keys named as users would read them,
+// which only differs from bubbletea's names for the spacebar.
+func keyNames(keys []string) []string {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key
+		if key == " " {
+			names[i] = "space"
+		}
+	}
+	return names
+}
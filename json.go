@@ -0,0 +1,152 @@
+package gostructui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// ValuesJSON marshals the current field values into a JSON object
+// keyed by the names of the struct fields, as read back by
+// LoadValuesJSON. Dates are written as RFC 3339 strings, durations
+// as strings such as "1m30s", values of types implementing
+// FieldFormatter as their Format text, and pointer fields still
+// unset as null. It may be called while the menu runs, as when
+// saving a draft of the form.
+func (m TModelStructMenu) ValuesJSON() ([]byte, error) {
+	return json.Marshal(m.values())
+}
+
+// values maps the names of the fields to their current values in
+// the form shared by ValuesJSON and ValuesYAML.
+func (m TModelStructMenu) values() map[string]any {
+	values := make(map[string]any, len(m.menuFields))
+	for i := range m.menuFields {
+		f := m.getFieldAtIndex(i)
+		values[f.name] = f.encodedValue()
+	}
+	return values
+}
+
+// encodedValue returns the value of the field in the form it is
+// written out to documents in: nil for a pointer field still unset,
+// durations and values of types implementing FieldFormatter as text,
+// and anything else as is.
+func (f *menuField) encodedValue() any {
+	switch {
+	case f.isUnset():
+		return nil
+	case f.kind == FieldDuration:
+		return f.d.String()
+	case f.kind == FieldCustom:
+		return f.format()
+	}
+	return f.value()
+}
+
+// LoadValuesJSON prefills the menu from a JSON object keyed by the
+// names of the struct fields (dotted for fields of nested structs),
+// as when resuming a form saved earlier. Numbers are converted to
+// the type of the field they fill, dates are read as RFC 3339
+// strings, durations as strings such as "1m30s", and values of
+// types implementing FieldFormatter as text to Parse. Keys matching
+// no field are recorded in Warnings and otherwise ignored, as are
+// null values and read-only fields.
+// Values that don't fit their fields don't stop the rest from
+// being loaded; their errors are joined into the one returned.
+func (m *TModelStructMenu) LoadValuesJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	return m.loadValues("JSON", slices.Sorted(maps.Keys(raw)), func(f *menuField, key string) error {
+		return f.loadJSON(raw[key])
+	})
+}
+
+// loadValues fills the fields named by keys using load, recording
+// keys matching no field as warnings, as LoadValuesJSON and
+// LoadValuesYAML do for documents of the format named.
+func (m *TModelStructMenu) loadValues(format string, keys []string, load func(f *menuField, key string) error) error {
+	var errs []error
+	for _, key := range keys {
+		f := m.getFieldByName(key)
+		if f == nil {
+			m.warnings = append(m.warnings, fmt.Sprintf("%s key '%s' matches no field", format, key))
+			continue
+		}
+		if f.isReadOnly() {
+			continue
+		}
+		if err := load(f, key); err != nil {
+			errs = append(errs, fmt.Errorf("cannot load field '%s' from %s: %w", key, format, err))
+		}
+	}
+	if err := m.recompute(); err != nil {
+		errs = append(errs, err)
+	}
+	m.checkValidity()
+	return errors.Join(errs...)
+}
+
+// loadJSON sets the field to the JSON value given, which is left
+// alone if null. The value must be of the JSON type matching the
+// kind of the field.
+func (f *menuField) loadJSON(data json.RawMessage) error {
+	if string(data) == "null" {
+		return nil
+	}
+	switch f.kind {
+	case FieldString:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return f.setValue(s)
+	case FieldBool:
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil {
+			return err
+		}
+		return f.setValue(b)
+	case FieldInt, FieldUint:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		return f.setText(n.String())
+	case FieldTime:
+		var t time.Time
+		if err := json.Unmarshal(data, &t); err != nil {
+			return err
+		}
+		return f.setValue(t)
+	case FieldDuration, FieldCustom:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return f.setText(s)
+	case FieldList:
+		var list []string
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		// empty entries are dropped, as when editing
+		if err := f.setValue(list); err != nil {
+			return err
+		}
+		f.commitList()
+	case FieldMap:
+		var m map[string]string
+		if err := json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+		return f.setValue(m)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package gostructui
+
+import (
+	"slices"
+	"testing"
+)
+
+type loadForm struct {
+	Nick *string
+	Tags []string
+}
+
+func TestLoadValuesJSONSetsFields(t *testing.T) {
+	empty := ""
+	saved, err := newTestMenu(t, &loadForm{Nick: &empty}).ValuesJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var obj loadForm
+	m := newTestMenu(t, &obj)
+	if err := m.LoadValuesJSON(saved); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.LoadValuesJSON([]byte(`{"Tags": ["a", "", "b"]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatal(err)
+	}
+	if obj.Nick == nil || *obj.Nick != "" {
+		t.Errorf("Nick saved as \"\" loaded as %v", obj.Nick)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(obj.Tags, want) {
+		t.Errorf("Tags = %q, want %q", obj.Tags, want)
+	}
+}
@@ -0,0 +1,110 @@
+package gostructui
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap holds the keys bound to each action of the menu, named
+// as bubbletea names them (e.g. "enter", "ctrl+c", "q"). A nil
+// binding falls back to its default, while an empty, non-nil
+// binding disables the action.
+type KeyMap struct {
+	ToggleEdit []string // enter edit mode on a field, or commit the edit
+	Save       []string // save and quit
+	Cancel     []string // quit without saving
+	Up         []string // move to the field above
+	Down       []string // move to the field below
+	PrevColumn []string // move to the column on the left, if laid out in columns
+	NextColumn []string // move to the column on the right, if laid out in columns
+	PrevField  []string // tab back to the previous editable field
+	NextField  []string // tab forward to the next editable field
+	First      []string // jump to the first field
+	Last       []string // jump to the last field
+	PageUp     []string // move up by Settings.PageSize fields
+	PageDown   []string // move down by Settings.PageSize fields
+	Decrease   []string // step a numeric or option field down
+	Increase   []string // step a numeric or option field up
+	ToggleBool []string // flip a bool field without entering edit mode
+	ResetField []string // restore the original value of a field
+	ResetAll   []string // restore the original values of all fields
+	Back       []string // return to the previous page of a wizard
+	Filter     []string // start typing a filter narrowing the fields shown
+	ClearField []string // clear the value being typed into a field
+	DeleteWord []string // delete the word before the caret while typing
+	Help       []string // show or hide the list of keybindings
+	Copy       []string // copy the value of a field to the clipboard
+}
+
+// DefaultKeyMap returns the keys the menu is bound to by default.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		ToggleEdit: []string{"enter"},
+		Save:       []string{"s"},
+		Cancel:     []string{"q", "ctrl+c"},
+		Up:         []string{"up", "k"},
+		Down:       []string{"down", "j"},
+		PrevColumn: []string{"shift+left", "H"},
+		NextColumn: []string{"shift+right", "L"},
+		PrevField:  []string{"shift+tab"},
+		NextField:  []string{"tab"},
+		First:      []string{"home"},
+		Last:       []string{"end"},
+		PageUp:     []string{"pgup"},
+		PageDown:   []string{"pgdown"},
+		Decrease:   []string{"left", "h"},
+		Increase:   []string{"right", "l"},
+		ToggleBool: []string{" "},
+		ResetField: []string{"r"},
+		ResetAll:   []string{"R"},
+		Back:       []string{"b"},
+		Filter:     []string{"/"},
+		ClearField: []string{"ctrl+u"},
+		DeleteWord: []string{"ctrl+w"},
+		Help:       []string{"?"},
+		Copy:       []string{"y"},
+	}
+}
+
+// withDefaults returns a copy of the key map
+// with nil bindings set to their defaults.
+func (k KeyMap) withDefaults() KeyMap {
+	defaults := DefaultKeyMap()
+	for _, pair := range []struct{ binding, def *[]string }{
+		{&k.ToggleEdit, &defaults.ToggleEdit},
+		{&k.Save, &defaults.Save},
+		{&k.Cancel, &defaults.Cancel},
+		{&k.Up, &defaults.Up},
+		{&k.Down, &defaults.Down},
+		{&k.PrevColumn, &defaults.PrevColumn},
+		{&k.NextColumn, &defaults.NextColumn},
+		{&k.PrevField, &defaults.PrevField},
+		{&k.NextField, &defaults.NextField},
+		{&k.First, &defaults.First},
+		{&k.Last, &defaults.Last},
+		{&k.PageUp, &defaults.PageUp},
+		{&k.PageDown, &defaults.PageDown},
+		{&k.Decrease, &defaults.Decrease},
+		{&k.Increase, &defaults.Increase},
+		{&k.ToggleBool, &defaults.ToggleBool},
+		{&k.ResetField, &defaults.ResetField},
+		{&k.ResetAll, &defaults.ResetAll},
+		{&k.Back, &defaults.Back},
+		{&k.Filter, &defaults.Filter},
+		{&k.ClearField, &defaults.ClearField},
+		{&k.DeleteWord, &defaults.DeleteWord},
+		{&k.Help, &defaults.Help},
+		{&k.Copy, &defaults.Copy},
+	} {
+		if *pair.binding == nil {
+			*pair.binding = *pair.def
+		}
+	}
+	return k
+}
+
+// keyIn reports whether the key pressed is one of the given keys.
+func keyIn(msg tea.KeyMsg, keys []string) bool {
+	return slices.Contains(keys, msg.String())
+}
@@ -0,0 +1,95 @@
+package gostructui
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var listType = reflect.TypeOf([]string(nil))
+
+// isListType reports whether values of type t, a slice
+// of strings, can be exposed to users as a list field.
+func isListType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String && listType.ConvertibleTo(t)
+}
+
+// handleListKey applies a key pressed while editing a list field.
+// Up and down move between the entries and the "+ add" row after
+// them, delete removes the entry under the cursor, and anything
+// else is typed into that entry. Typing on the "+ add" row starts
+// a new entry.
+func (f *menuField) handleListKey(key string) {
+	switch key {
+	case "up":
+		f.listPos = max(f.listPos-1, 0)
+	case "down":
+		f.listPos = min(f.listPos+1, len(f.list))
+	case "delete":
+		if f.listPos < len(f.list) {
+			f.list = slices.Delete(f.list, f.listPos, f.listPos+1)
+		}
+	default:
+		if len([]rune(key)) != 1 {
+			return
+		}
+		if f.listPos == len(f.list) {
+			f.list = append(f.list, "")
+		}
+		f.list[f.listPos] += key
+	}
+}
+
+// handleListBackspace deletes the last character
+// of the list entry under the cursor.
+func (f *menuField) handleListBackspace() {
+	if f.listPos == len(f.list) {
+		return
+	}
+	runes := []rune(f.list[f.listPos])
+	if len(runes) > 0 {
+		f.list[f.listPos] = string(runes[:len(runes)-1])
+	}
+}
+
+// addsListItem reports whether the cursor of a list field
+// being edited is on its "+ add" row, and if so, adds an
+// empty entry there for the user to type into.
+func (f *menuField) addsListItem() bool {
+	if f.kind != FieldList && f.kind != FieldMap || f.listPos != len(f.list) {
+		return false
+	}
+	f.list = append(f.list, "")
+	return true
+}
+
+// commitList drops any entries left empty once an edit is done.
+func (f *menuField) commitList() {
+	f.list = slices.DeleteFunc(f.list, func(item string) bool {
+		return item == ""
+	})
+}
+
+// renderList renders the entries of a list field on one line.
+func (f *menuField) renderList() string {
+	return strings.Join(f.list, ", ")
+}
+
+// renderListEdit renders the entries of a list field being edited,
+// one per line beneath the row of the field, followed by the row
+// to add entries from.
+func (f *menuField) renderListEdit(iBeamChar string) string {
+	s := fmt.Sprintf("%d entries", len(f.list))
+	for i, item := range append(slices.Clone(f.list), "+ add") {
+		cursor := "  "
+		if i == f.listPos {
+			cursor = "> "
+			if i < len(f.list) {
+				item += iBeamChar
+			}
+		}
+		s += "\n" + cursor + item
+	}
+	return s
+}
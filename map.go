@@ -0,0 +1,81 @@
+package gostructui
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var mapType = reflect.TypeOf(map[string]string(nil))
+
+// isMapType reports whether values of type t, a map of strings
+// to strings, can be exposed to users as a map field.
+func isMapType(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String &&
+		t.Elem().Kind() == reflect.String && mapType.ConvertibleTo(t)
+}
+
+// mapEntries returns the pairs of m as "key=value" entries,
+// sorted by key so that they are always listed in the same order.
+// Map fields are edited as lists of such entries.
+func mapEntries(m map[string]string) []string {
+	var entries []string
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		entries = append(entries, key+"="+m[key])
+	}
+	return entries
+}
+
+// parseMapEntries reads "key=value" entries back into a map,
+// skipping empty ones. An entry without an "=" or with an empty
+// key is refused, as is a key given twice, rather than have one
+// of its values silently lost.
+func parseMapEntries(entries []string) (map[string]string, error) {
+	m := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if entry == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(entry, "=")
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("entry '%s' must be written as key=value", entry)
+		case key == "":
+			return nil, fmt.Errorf("entry '%s' has no key", entry)
+		}
+		if _, dup := m[key]; dup {
+			return nil, fmt.Errorf("key '%s' is given more than once", key)
+		}
+		m[key] = value
+	}
+	return m, nil
+}
+
+// mapValue returns the entries of a map field as a map, which is
+// nil if there are none. Entries that don't parse, as while the
+// field is being edited, are left out.
+func (f *menuField) mapValue() map[string]string {
+	if len(f.list) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(f.list))
+	for _, entry := range f.list {
+		if key, value, ok := strings.Cut(entry, "="); ok && key != "" {
+			m[key] = value
+		}
+	}
+	return m
+}
+
+// commitMap checks the entries of a map field once an edit is
+// done, and sorts them by key.
+func (f *menuField) commitMap() error {
+	m, err := parseMapEntries(f.list)
+	if err != nil {
+		return err
+	}
+	f.list = mapEntries(m)
+	return nil
+}
@@ -3,13 +3,22 @@
 package gostructui
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"regexp"
 	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type MenuSettings struct {
@@ -18,38 +27,563 @@ type MenuSettings struct {
 	IBeamChar      string // character shown right of text during edit
 	TabAfterEntry  bool   // whether or not to jump to the next field after tabAfterEntry
 	Header         string // message to display above the struct menu
+
+	// KeyInterceptor, if set, sees every key pressed during navigation
+	// before the menu does. If it reports the key as handled, the default
+	// handling is skipped and the returned command is passed to bubbletea.
+	KeyInterceptor func(msg tea.KeyMsg, m *TModelStructMenu) (handled bool, cmd tea.Cmd)
+
+	// UseViewport always renders the fields inside a scrollable viewport
+	// sized to the terminal. Menus too tall for the terminal are rendered
+	// that way regardless, which keeps the cursor in view on long forms.
+	// When fields are clipped, "↑ more" and "↓ more" mark the clipped ends.
+	// Mouse-wheel scrolling requires mouse support to be enabled on the
+	// bubbletea program (e.g. with tea.WithMouseCellMotion).
+	UseViewport bool
+
+	// SkipReadOnlyInNav makes navigation with up and down jump over
+	// fields that cannot be edited, as tabbing always does.
+	SkipReadOnlyInNav bool
+
+	// WrapNavigation moves the cursor from the last field to
+	// the first when moving down, and from the first field to
+	// the last when moving up.
+	WrapNavigation bool
+
+	// PageSize is the number of fields the cursor
+	// moves by on PageUp and PageDown.
+	PageSize int
+
+	// QuitOnEscape makes the escape key quit without saving
+	// while navigating. During an edit, escape always just
+	// abandons the edit.
+	QuitOnEscape bool
+
+	// Keys binds the actions of the menu to keys.
+	// Bindings left nil fall back to DefaultKeyMap.
+	Keys KeyMap
+
+	// ShowDiffOnSave asks users to review the old and new values
+	// of every changed field, and to confirm them, before saving.
+	ShowDiffOnSave bool
+
+	// Styles colors and emphasizes the parts of the menu.
+	// Parts without a style are rendered as plain text.
+	Styles MenuStyles
+
+	// UseJSONNames names fields without an smname tag after their
+	// json struct tag, if they have one, and leaves out fields
+	// tagged json:"-" as if they had been blacklisted.
+	UseJSONNames bool
+
+	// ShowProgress shows the position of the cursor
+	// among the fields, as in "Field 4 / 12".
+	ShowProgress bool
+
+	// InlineDescriptions shows the smdes description of every
+	// field dimmed beneath its row, rather than only that of
+	// the field under the cursor below the menu.
+	InlineDescriptions bool
+
+	// OnChange is called with the name and new value of each field
+	// whose value changed while handling a message, in menu order.
+	// It is called once the update is complete, so values read back
+	// through FieldValue (including those of computed fields) are
+	// already current. Typed edits are reported keystroke by
+	// keystroke, including backspaces, with the value the text typed
+	// so far stands for; text that is not a valid value yet, such as
+	// a lone "-", is not reported. The value is reported again once
+	// committed, and the original one if the edit is abandoned.
+	// Changes made by stepping, toggling or resetting a field are
+	// reported as well, but moving between fields is not.
+	OnChange func(fieldName string, newValue any)
+
+	// OnSubmit is called with the values of all fields, keyed by
+	// field name, when users save. It is only called once every
+	// field passes its own validation, which makes it the place for
+	// checks spanning several fields. An error it returns blocks the
+	// save, and is shown below the fields.
+	OnSubmit func(values map[string]any) error
+
+	// ConfirmOnCancel asks users to confirm that they mean
+	// to discard their changes before quitting without saving.
+	ConfirmOnCancel bool
+
+	// ValidateOnBlur checks each field against its constraints
+	// and validators as soon as the cursor leaves it, or an edit
+	// of it ends, rather than only once users save. Saving still
+	// checks every field again.
+	ValidateOnBlur bool
+
+	// BlockSaveWhenInvalid ignores the save key for as long as
+	// any field fails its constraints or validators, which the
+	// save hint below the fields then says. The hint follows the
+	// values as they change, so saving unlocks once all is well.
+	BlockSaveWhenInvalid bool
+
+	// MouseClicks lets users click a field to move the cursor
+	// to it, and click the checkbox of a bool field to flip it.
+	// Init turns on mouse tracking for this, which also makes
+	// the mouse wheel scroll the viewport.
+	MouseClicks bool
+
+	// CopyToClipboard puts text on the system clipboard when
+	// users copy the value of a field. If nil, the text is sent
+	// to the terminal to copy as an OSC 52 escape sequence,
+	// which most modern terminals support, even over SSH.
+	CopyToClipboard func(text string) error
+
+	// DigitSeparator separates the groups of thousands in the
+	// values of fields tagged smgroupdigits, as in "1,200,000".
+	// It defaults to a comma if left empty.
+	DigitSeparator string
+
+	// FooterText is shown below the fields, one hint per line. The
+	// placeholders {save}, {cancel}, {help}, {edit}, {filter} and
+	// {back} are replaced by the first key bound to each action, and
+	// lines naming an action whose binding is disabled are left out.
+	// The line naming {save} also shows how many fields failed
+	// validation. Init sets it to DefaultFooterText; leave it empty
+	// to show no hints at all.
+	FooterText string
+
+	// BoolSelectors shows bool fields as a selector between
+	// two choices, as in "< Yes >", rather than as a checkbox.
+	// The choices are named by the smbool tag if present. Like
+	// fields with smoptions, they flip with Keys.Decrease and
+	// Keys.Increase, as well as in edit mode.
+	BoolSelectors bool
+
+	// ToastDuration is how long toasts, such as the one confirming
+	// a copy, are shown below the menu before going away by
+	// themselves. Any key pressed sooner dismisses them as well.
+	// It defaults to two seconds if left unset.
+	ToastDuration time.Duration
+
+	// EmptyMarker, if set, is shown dimmed in place of the zero
+	// value of int, unsigned int, duration and bool fields users
+	// have neither edited nor changed, as in "—", so that values
+	// still to be filled in stand apart from ones set to zero. The
+	// real value shows while the cursor is on the field.
+	EmptyMarker string
+
+	// Columns lays the fields out in up to this many columns,
+	// filled top to bottom, which suits short fields on wide
+	// terminals. Fewer columns are used when the terminal is too
+	// narrow for them all, down to the usual single column.
+	// Keys.PrevColumn and Keys.NextColumn move between columns.
+	Columns int
 }
 
+// placeholderStyle dims placeholders shown in empty fields.
+var placeholderStyle = lipgloss.NewStyle().Faint(true)
+
+// readOnlyStyle mutes the values of fields users cannot edit.
+var readOnlyStyle = lipgloss.NewStyle().Faint(true)
+
+// descriptionStyle dims descriptions shown beneath the rows of fields.
+var descriptionStyle = lipgloss.NewStyle().Faint(true)
+
+// errorStyle marks fields failing validation, and the problems found.
+var errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+
+// groupStyle emphasizes the headings of groups of fields.
+var groupStyle = lipgloss.NewStyle().Bold(true)
+
 type FieldKind int
 
 const (
 	FieldString FieldKind = iota
 	FieldBool
 	FieldInt
+	FieldUint
+	FieldTime
+	FieldList
+	FieldDuration
+	FieldCustom
+	FieldMap
 )
 
 type menuField struct {
-	kind FieldKind // value assigned to field
-	s    string    // possible string value
-	b    bool      // possible bool value
-	i    int       // possible int value
+	kind   FieldKind     // value assigned to field
+	s      string        // possible string value
+	b      bool          // possible bool value
+	i      int64         // possible int value
+	u      uint64        // possible unsigned int value
+	bits   int           // bit width of an int or unsigned int field
+	t      time.Time     // possible time value
+	d      time.Duration // possible duration value
+	list   []string      // possible list of strings value
+	custom reflect.Value // pointer to a possible value of a type implementing FieldFormatter
+
+	layout string // layout of a time value, pulled from smtimeformat tag
+	part   int    // date component focused while editing a time value
 
 	editBuf string // buffer for editing this field
+	preEdit any    // value of the field when the current edit began
+	caret   int    // rune position of the caret in the buffer of a string field
+	listPos int    // index of the entry being edited in a list field
 	errBuf  string // potential error from bad input
+	notice  string // feedback on a key, passed on as a toast once the key is handled
+
+	name    string              // name of the struct field, dotted if nested (e.g. "Address.City")
+	tag     reflect.StructTag   // full tag of the struct field
+	parents []reflect.StructTag // full tags of the nested structs holding the struct field, outermost first
+	smName  string              // description pulled from smname tag
+	smDes   string              // description pulled from smdes tag
+
+	regex     *regexp.Regexp // pattern string values must match, pulled from smregex tag
+	options   []string       // allowed string values, pulled from smoptions tag
+	required  bool           // whether a zero value blocks saving, pulled from smrequired tag
+	minVal    *int64         // lower bound of a numeric value, pulled from smmin tag
+	maxVal    *int64         // upper bound of a numeric value, pulled from smmax tag
+	maxLen    int            // maximum length of a string value in runes, pulled from smmaxlen tag
+	stepBy    int            // amount a numeric value is stepped by, pulled from smstep tag
+	mask      bool           // whether a string value is hidden on screen, pulled from smmask tag
+	suggest   []string       // completions offered while typing a string value, pulled from smsuggest tag
+	multiline bool           // whether a string value spans several lines, pulled from smmultiline tag
+	percent   bool           // whether a numeric value is shown as a percentage bar, pulled from smpercent tag
+	path      string         // constraint on a string value as a filesystem path, pulled from smpath tag
+	showIf    *condition     // condition on another field for this one to be shown, pulled from smshowif tag
+	touched   bool           // whether users have edited or changed the value, tracked if Settings.EmptyMarker is set
+	grouped   bool           // whether a numeric value is shown with its thousands separated, pulled from smgroupdigits tag
+	base      int            // base a numeric value is shown and typed in, pulled from smbase tag; 0 means decimal
+	labels    []string       // labels shown for true and false, pulled from smbool tag
+
+	placeholder string // hint shown in place of an empty value, pulled from smplaceholder tag
+	readOnly    bool   // whether the value is shown but not editable, pulled from smreadonly tag
+	order       *int   // position of the field in the menu, pulled from smorder tag
+	group       string // section the field is listed under, pulled from smgroup tag
+
+	orig  any  // value of the field when the menu was created
+	def   any  // value pulled from smdefault tag, if any
+	ptr   bool // whether the struct field is a pointer to the value
+	isNil bool // whether the struct field was a nil pointer when the menu was created
+	set   bool // whether a nil pointer field was given a value, even its zero value
+
+	compute    func(m *TModelStructMenu) any // derives the value from other fields, if registered
+	validators []func(v any) error           // checks the value on save, if registered
+}
+
+// value returns the current value of the field
+// as the Go type it was read from.
+func (f *menuField) value() any {
+	switch f.kind {
+	case FieldString:
+		return f.s
+	case FieldBool:
+		return f.b
+	case FieldInt:
+		return f.i
+	case FieldUint:
+		return f.u
+	case FieldTime:
+		return f.t
+	case FieldList:
+		return slices.Clone(f.list)
+	case FieldMap:
+		return f.mapValue()
+	case FieldDuration:
+		return f.d
+	case FieldCustom:
+		return f.custom.Elem().Interface()
+	default:
+		return nil
+	}
+}
+
+// setValue assigns v to the field, provided it is
+// of a Go type matching the kind of the field.
+func (f *menuField) setValue(v any) error {
+	rv := reflect.ValueOf(v)
+	switch {
+	case f.kind == FieldString && rv.Kind() == reflect.String:
+		f.s = rv.String()
+	case f.kind == FieldBool && rv.Kind() == reflect.Bool:
+		f.b = rv.Bool()
+	case f.kind == FieldInt && rv.CanInt():
+		f.i = rv.Int()
+	case f.kind == FieldUint && rv.CanUint():
+		f.u = rv.Uint()
+	case f.kind == FieldTime && rv.Type() == timeType:
+		f.t = rv.Interface().(time.Time)
+	case f.kind == FieldCustom && rv.Type() == f.custom.Type().Elem():
+		f.custom = reflect.New(rv.Type())
+		f.custom.Elem().Set(rv)
+	case f.kind == FieldDuration && rv.Type() == durationType:
+		f.d = rv.Interface().(time.Duration)
+	case f.kind == FieldList && rv.Kind() == reflect.Slice && rv.CanConvert(listType):
+		f.list = slices.Clone(rv.Convert(listType).Interface().([]string))
+	case f.kind == FieldMap && rv.Kind() == reflect.Map && rv.CanConvert(mapType):
+		f.list = mapEntries(rv.Convert(mapType).Interface().(map[string]string))
+	default:
+		return fmt.Errorf("type mismatch for field '%s': cannot assign %T", f.name, v)
+	}
+	f.set = true
+	return nil
+}
+
+// setText parses s as a value for the field and assigns it.
+func (f *menuField) setText(s string) error {
+	switch f.kind {
+	case FieldString:
+		f.s = s
+	case FieldBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		f.b = b
+	case FieldInt:
+		i, err := f.parseInt(s)
+		if err != nil {
+			return err
+		}
+		f.i = i
+	case FieldUint:
+		u, err := f.parseUint(s)
+		if err != nil {
+			return err
+		}
+		f.u = u
+	case FieldTime:
+		t, err := f.parseTime(s)
+		if err != nil {
+			return err
+		}
+		f.t = t
+	case FieldDuration:
+		d, err := parseDuration(s)
+		if err != nil {
+			return err
+		}
+		f.d = d
+	case FieldCustom:
+		p, err := f.parseCustom(s)
+		if err != nil {
+			return err
+		}
+		f.custom = p
+	case FieldList:
+		// empty entries are dropped, as when editing, so that
+		// empty text reads as an empty list
+		f.list = nil
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				f.list = append(f.list, item)
+			}
+		}
+	case FieldMap:
+		var entries []string
+		for _, entry := range strings.Split(s, ",") {
+			entries = append(entries, strings.TrimSpace(entry))
+		}
+		m, err := parseMapEntries(entries)
+		if err != nil {
+			return err
+		}
+		f.list = mapEntries(m)
+	}
+	f.set = true
+	return nil
+}
+
+// text returns the value of the field as text,
+// in the form setText reads it back from.
+func (f *menuField) text() string {
+	switch f.kind {
+	case FieldString:
+		return f.s
+	case FieldBool:
+		return strconv.FormatBool(f.b)
+	case FieldInt, FieldUint:
+		return f.formatNumber()
+	case FieldTime:
+		return f.t.Format(f.layout)
+	case FieldDuration:
+		return f.d.String()
+	case FieldCustom:
+		return f.format()
+	case FieldList, FieldMap:
+		return strings.Join(f.list, ", ")
+	default:
+		return ""
+	}
+}
+
+// isDirty reports whether the field value differs
+// from the value it held when the menu was created.
+func (f *menuField) isDirty() bool {
+	return !sameValue(f.value(), f.orig)
+}
+
+// isUnset reports whether the field is a nil pointer that has
+// not been given a value, not even by entering its zero value.
+func (f *menuField) isUnset() bool {
+	return f.isNil && !f.set && !f.isDirty()
+}
 
-	name   string // name of the struct field
-	smName string // description pulled from smname tag
-	smDes  string // description pulled from smdes tag
+// sameValue reports whether a and b are the same field value.
+// Lists are equal if they hold the same entries, regardless of
+// whether they are nil or empty.
+func sameValue(a, b any) bool {
+	if list, ok := a.([]string); ok {
+		other, ok := b.([]string)
+		return ok && slices.Equal(list, other)
+	}
+	if t := reflect.TypeOf(a); t != nil && !t.Comparable() {
+		return reflect.DeepEqual(a, b)
+	}
+	return a == b
+}
+
+// isReadOnly reports whether the user is kept from editing the field.
+func (f *menuField) isReadOnly() bool {
+	return f.readOnly || f.compute != nil
+}
+
+// maxUint returns the largest value an unsigned int field can hold.
+func (f *menuField) maxUint() uint64 {
+	return math.MaxUint64 >> (64 - f.bits)
+}
+
+// intLimits returns the smallest and largest
+// values a signed int field can hold.
+func (f *menuField) intLimits() (int64, int64) {
+	maxInt := int64(math.MaxInt64) >> (64 - f.bits)
+	return -maxInt - 1, maxInt
+}
+
+// cycleOption returns the allowed value delta places away
+// from current, wrapping around the list of options. A value
+// that is not among the options moves to the first one.
+func (f *menuField) cycleOption(current string, delta int) string {
+	i := slices.Index(f.options, current)
+	if i < 0 {
+		return f.options[0]
+	}
+	n := len(f.options)
+	return f.options[((i+delta)%n+n)%n]
+}
+
+// step nudges the value of a numeric field by delta
+// multiples of its smstep tag, stopping at the bounds
+// of its type rather than wrapping around them.
+func (f *menuField) step(delta int) {
+	if f.kind == FieldInt || f.kind == FieldUint {
+		delta *= max(f.stepBy, 1)
+	}
+	switch f.kind {
+	case FieldString:
+		if len(f.options) > 0 {
+			f.s = f.cycleOption(f.s, delta)
+		}
+	case FieldInt:
+		minInt, maxInt := f.intLimits()
+		d := int64(delta)
+		switch {
+		case d > 0 && f.i > maxInt-d:
+			f.i = maxInt
+		case d < 0 && f.i < minInt-d:
+			f.i = minInt
+		default:
+			f.i += d
+		}
+		f.i = f.clamp(f.i)
+	case FieldUint:
+		if delta < 0 {
+			if d := uint64(-delta); d < f.u {
+				f.u -= d
+			} else {
+				f.u = 0
+			}
+		} else if d := uint64(delta); d < f.maxUint()-f.u {
+			f.u += d
+		} else {
+			f.u = f.maxUint()
+		}
+		if v := uintAsInt64(f.u); f.clamp(v) != v {
+			f.u = uint64(max(f.clamp(v), 0))
+		}
+	}
 }
 
 func (f *menuField) handleChar(char string) {
 	switch f.kind {
 	case FieldInt:
-		if (char >= "0" && char <= "9") || (char == "-" && len(f.editBuf) == 0) {
-			f.editBuf += string(char)
+		if f.isBaseDigits(char) {
+			f.editBuf += strings.ToLower(char)
+			f.clampBuf()
+		} else if char == "-" {
+			// toggle the sign, which always leads the digits
+			if strings.HasPrefix(f.editBuf, "-") {
+				f.editBuf = f.editBuf[1:]
+			} else {
+				f.editBuf = "-" + f.editBuf
+			}
+			f.clampBuf()
+		} else {
+			f.refuseNonDigit(char)
 		}
+	case FieldUint:
+		if f.isBaseDigits(char) {
+			f.editBuf += strings.ToLower(char)
+			f.clampBuf()
+		} else {
+			f.refuseNonDigit(char)
+		}
+	case FieldTime:
+		f.handleTimeKey(char)
+	case FieldList, FieldMap:
+		f.handleListKey(char)
+	case FieldDuration:
+		f.handleDurationKey(char)
+	case FieldCustom:
+		f.handleCustomKey(char)
 	case FieldString:
-		f.editBuf += string(char)
+		// fields with options are cycled through, never typed into
+		if len(f.options) > 0 {
+			switch char {
+			case "left", "up":
+				f.editBuf = f.cycleOption(f.editBuf, -1)
+			case "right", "down":
+				f.editBuf = f.cycleOption(f.editBuf, 1)
+			}
+			return
+		}
+		runes := []rune(f.editBuf)
+		switch char {
+		case "left":
+			f.caret = max(f.caret-1, 0)
+			return
+		case "right":
+			f.caret = min(f.caret+1, len(runes))
+			return
+		case "up", "down":
+			if f.multiline && char == "up" {
+				f.moveCaretLine(-1)
+			} else if f.multiline {
+				f.moveCaretLine(1)
+			}
+			return
+		case "delete":
+			// delete the character after the caret
+			if f.caret < len(runes) {
+				f.editBuf = string(runes[:f.caret]) + string(runes[f.caret+1:])
+			}
+			return
+		}
+		// drop keystrokes once the value is as long as allowed
+		if f.maxLen > 0 && len(runes)+len([]rune(char)) > f.maxLen {
+			return
+		}
+		edited := string(runes[:f.caret]) + char + string(runes[f.caret:])
+		// refuse keystrokes that would break an otherwise matching value
+		if f.regex != nil && f.regex.MatchString(f.editBuf) && !f.regex.MatchString(edited) {
+			return
+		}
+		f.editBuf = edited
+		f.caret += len([]rune(char))
 	case FieldBool:
 		switch char {
 		case "t", "1":
@@ -62,57 +596,382 @@ func (f *menuField) handleChar(char string) {
 	}
 }
 
+// editKeys names the keys other than characters
+// that fields handle while being edited.
+var editKeys = []string{"left", "right", "up", "down", "delete"}
+
+// insertText types text into the field as is, one character at a
+// time, so that typed or pasted text such as "left" or "delete" is
+// never taken for the name of a key.
+func (f *menuField) insertText(runes []rune) {
+	for _, r := range runes {
+		f.handleChar(string(r))
+	}
+}
+
+// refuseNonDigit tells users why the character typed into an
+// int field was dropped. Keys like "left" pass without a word.
+func (f *menuField) refuseNonDigit(char string) {
+	switch {
+	case slices.Contains(editKeys, char):
+	case char == "." || char == ",":
+		f.notice = "whole numbers only"
+	case f.numBase() == 16:
+		f.notice = "hex digits only"
+	case f.numBase() == 2:
+		f.notice = "binary digits only"
+	default:
+		f.notice = "digits only"
+	}
+}
+
 func (f *menuField) handleBackspace() {
-	if len(f.editBuf) == 0 {
+	if f.kind == FieldString {
+		// delete the character before the caret
+		if f.caret == 0 {
+			return
+		}
+		runes := []rune(f.editBuf)
+		f.editBuf = string(runes[:f.caret-1]) + string(runes[f.caret:])
+		f.caret--
 		return
 	}
-	f.editBuf = f.editBuf[:len(f.editBuf)-1]
+	if f.kind == FieldList || f.kind == FieldMap {
+		f.handleListBackspace()
+		return
+	}
+	runes := []rune(f.editBuf)
+	if len(runes) == 0 {
+		return
+	}
+	f.editBuf = string(runes[:len(runes)-1])
+	// a sign with no digits left to it goes as well
+	if f.kind == FieldInt && f.editBuf == "-" {
+		f.editBuf = ""
+	}
 }
 
-func (f *menuField) render(editing bool, iBeamChar string) string {
+// clearEdit empties the buffer of the field being edited, leaving
+// an int at zero, or the entry under the cursor of a list. Dates,
+// bools and strings with options, which are never empty, are left
+// as they are.
+func (f *menuField) clearEdit() {
 	switch f.kind {
-	case FieldInt:
+	case FieldString:
+		if len(f.options) == 0 {
+			f.editBuf, f.caret = "", 0
+		}
+	case FieldInt, FieldUint, FieldDuration, FieldCustom:
+		f.editBuf = ""
+	case FieldList, FieldMap:
+		if f.listPos < len(f.list) {
+			f.list[f.listPos] = ""
+		}
+	}
+}
+
+// deleteWord deletes the word before the caret of a string field
+// being edited, back to the whitespace before it, along with any
+// whitespace between it and the caret.
+func (f *menuField) deleteWord() {
+	if f.kind != FieldString || len(f.options) > 0 {
+		return
+	}
+	runes := []rune(f.editBuf)
+	start := f.caret
+	for start > 0 && unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	for start > 0 && !unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	f.editBuf = string(runes[:start]) + string(runes[f.caret:])
+	f.caret = start
+}
+
+// render renders the value of the field, as it is
+// being edited if editing is set, laid out and
+// styled according to settings.
+func (f *menuField) render(editing bool, settings *MenuSettings) string {
+	iBeamChar, placeholder := settings.IBeamChar, settings.Styles.Placeholder
+	// a nil pointer reads as unset until given a value
+	if f.isUnset() && !editing {
+		return f.renderPlaceholder(placeholder)
+	}
+	switch f.kind {
+	case FieldInt, FieldUint:
+		if editing {
+			return f.withPrefix(f.editBuf) + iBeamChar
+		}
+		if f.percent {
+			return f.renderPercent()
+		}
+		return f.groupDigits(f.formatNumber(), settings.digitSeparator())
+	case FieldTime:
+		if editing {
+			return f.renderTimeEdit() + iBeamChar
+		}
+		return f.t.Format(f.layout)
+	case FieldDuration:
 		if editing {
 			return f.editBuf + iBeamChar
 		}
-		return strconv.Itoa(f.i)
-	case FieldString:
+		return f.d.String()
+	case FieldCustom:
 		if editing {
 			return f.editBuf + iBeamChar
 		}
-		return f.s
+		return f.format()
+	case FieldList, FieldMap:
+		if editing {
+			return f.renderListEdit(iBeamChar)
+		}
+		return f.renderList()
+	case FieldString:
+		if len(f.options) > 0 {
+			if editing {
+				return "< " + f.editBuf + " >"
+			}
+			return "< " + f.s + " >"
+		}
+		if f.multiline {
+			return f.renderTextArea(editing, iBeamChar, placeholder)
+		}
+		if editing {
+			runes := []rune(f.maskString(f.editBuf))
+			return string(runes[:f.caret]) + iBeamChar + string(runes[f.caret:]) + f.renderSuggestion(settings.Styles.Suggestion)
+		}
+		if f.s == "" {
+			return f.renderPlaceholder(placeholder)
+		}
+		return f.maskString(f.s)
 	case FieldBool:
+		if settings.BoolSelectors {
+			return f.renderBoolSelector()
+		}
 		if editing {
+			yes, no := "t", "f"
+			if f.labels != nil {
+				yes, no = f.labels[0], f.labels[1]
+			}
 			if f.b {
-				return "[t] ||  f "
+				return fmt.Sprintf("[%s] ||  %s ", yes, no)
 			}
-			return " t  || [f]"
+			return fmt.Sprintf(" %s  || [%s]", yes, no)
 		}
-		return fmt.Sprintf("%v", f.b)
+		return f.renderBool()
 	default:
 		return ""
 	}
 }
 
-func (f *menuField) commitEdit() {
+// groupDigits separates the thousands of the formatted
+// number s with sep, if the field is tagged smgroupdigits.
+// Otherwise, s is returned as is.
+func (f *menuField) groupDigits(s, sep string) string {
+	if !f.grouped || f.numBase() != 10 {
+		return s
+	}
+	return groupDigits(s, sep)
+}
+
+// renderBool renders the value of a bool field as a
+// checkbox, or as one of its labels if it has any.
+func (f *menuField) renderBool() string {
+	switch {
+	case f.labels != nil && f.b:
+		return f.labels[0]
+	case f.labels != nil:
+		return f.labels[1]
+	case f.b:
+		return "[x]"
+	default:
+		return "[ ]"
+	}
+}
+
+// renderBoolSelector renders the value of a bool field as the
+// current choice of a selector, as in "< Yes >", named by its
+// labels if it has any.
+func (f *menuField) renderBoolSelector() string {
+	yes, no := "Yes", "No"
+	if f.labels != nil {
+		yes, no = f.labels[0], f.labels[1]
+	}
+	if f.b {
+		return "< " + yes + " >"
+	}
+	return "< " + no + " >"
+}
+
+// renderPlaceholder renders the placeholder of the field in the
+// given style, or dimmed if there is none, to set it apart from
+// an actual value.
+func (f *menuField) renderPlaceholder(style *lipgloss.Style) string {
+	if f.placeholder == "" {
+		return ""
+	}
+	return styleOr(style, placeholderStyle).Render(f.placeholder)
+}
+
+// maskString hides s behind mask characters of equal length,
+// if the field is masked. Otherwise, s is returned as is.
+func (f *menuField) maskString(s string) string {
+	if !f.mask {
+		return s
+	}
+	return strings.Repeat("*", len([]rune(s)))
+}
+
+// beginEdit prepares the field for a new edit.
+// Ints start from an empty buffer, so that typed
+// digits build the new number from scratch, while
+// strings start from their value with the caret
+// placed at its end.
+func (f *menuField) beginEdit() {
+	f.touched = true
+	f.editBuf = ""
+	f.errBuf = ""
+	f.preEdit = f.value()
+	switch f.kind {
+	case FieldString:
+		f.editBuf = f.s
+		f.caret = len([]rune(f.s))
+	case FieldTime:
+		f.editBuf = f.t.Format(f.layout)
+		f.part = 0
+	case FieldDuration:
+		f.editBuf = f.d.String()
+	case FieldCustom:
+		f.editBuf = f.format()
+	case FieldList, FieldMap:
+		f.listPos = 0
+	}
+}
+
+// cancelEdit abandons the current edit, restoring
+// the value the field held when the edit began.
+func (f *menuField) cancelEdit() {
+	set := f.set
+	f.setValue(f.preEdit)
+	f.set = set
+	f.editBuf = ""
+	f.errBuf = ""
+}
+
+// reset restores the value the field held when the menu was created.
+func (f *menuField) reset() {
+	f.setValue(f.orig)
+	f.set = f.def != nil
+	f.editBuf = ""
+	f.errBuf = ""
+}
+
+// resetToDefault restores the value pulled from the smdefault
+// tag of the field, or the zero value if it has none.
+func (f *menuField) resetToDefault() {
+	if f.def != nil {
+		f.setValue(f.def)
+	} else {
+		f.setValue(reflect.Zero(reflect.TypeOf(f.value())).Interface())
+	}
+	f.set = f.def != nil
+	f.editBuf = ""
+	f.errBuf = ""
+}
+
+// commitEdit applies the edit buffer to the field value.
+// If the buffer holds an invalid value, the error is
+// recorded on the field and returned, and the field
+// value is left as it was.
+func (f *menuField) commitEdit() error {
 	switch f.kind {
 	case FieldInt:
-		if f.editBuf == "" || f.editBuf == "-" {
-			f.i = 0
-			return
+		var v int64
+		if f.editBuf != "" && f.editBuf != "-" {
+			var err error
+			if v, err = strconv.ParseInt(f.editBuf, f.numBase(), f.bits); err != nil {
+				f.errBuf = err.Error()
+				return err
+			}
 		}
-		v, err := strconv.Atoi(f.editBuf)
-		if err != nil {
+		if err := f.checkRange(v); err != nil {
 			f.errBuf = err.Error()
-			return
+			return err
 		}
 		f.i = v
+	case FieldUint:
+		var v uint64
+		if f.editBuf != "" {
+			var err error
+			if v, err = strconv.ParseUint(f.editBuf, f.numBase(), f.bits); err != nil {
+				f.errBuf = err.Error()
+				return err
+			}
+		}
+		if err := f.checkRange(uintAsInt64(v)); err != nil {
+			f.errBuf = err.Error()
+			return err
+		}
+		f.u = v
+	case FieldTime:
+		v, err := f.parseTime(f.editBuf)
+		if err != nil {
+			f.errBuf = err.Error()
+			return err
+		}
+		f.t = v
+	case FieldDuration:
+		v, err := parseDuration(f.editBuf)
+		if err != nil {
+			f.errBuf = err.Error()
+			return err
+		}
+		f.d = v
+	case FieldCustom:
+		p, err := f.parseCustom(f.editBuf)
+		if err != nil {
+			f.errBuf = err.Error()
+			return err
+		}
+		f.custom = p
+	case FieldList:
+		f.commitList()
+	case FieldMap:
+		if err := f.commitMap(); err != nil {
+			f.errBuf = err.Error()
+			return err
+		}
 	case FieldString:
+		if f.regex != nil && !f.regex.MatchString(f.editBuf) {
+			err := fmt.Errorf("value must match pattern %s", f.regex)
+			f.errBuf = err.Error()
+			return err
+		}
 		f.s = f.editBuf
 	}
 
+	f.set = true
 	f.editBuf = ""
 	f.errBuf = ""
+	return nil
+}
+
+// editedValue returns the value the field would hold were the edit
+// in progress committed, or false if the text typed so far does not
+// make a valid value yet.
+func (f *menuField) editedValue() (any, bool) {
+	switch f.kind {
+	case FieldString:
+		return f.editBuf, true
+	case FieldBool, FieldList, FieldMap:
+		return f.value(), true
+	}
+	edited := *f
+	if edited.commitEdit() != nil {
+		return nil, false
+	}
+	return edited.value(), true
 }
 
 // getFieldName returns a name for the menu field.
@@ -133,11 +992,33 @@ func (f *menuField) getFieldName() string {
 type TModelStructMenu struct {
 	// MENU STATE
 	// fields which can be edited; populated dynamically
-	menuFields     []menuField
-	cursor         int  // which field our cursor is pointing at
-	isEditingValue bool // tracks state of field editing
-	QuitWithCancel bool // can be used to communicate whether changes ought be saved
-	Settings       MenuSettings
+	menuFields       []menuField
+	order            []int // indices of menuFields in the order they are shown, gathered by group
+	cursor           int   // which field our cursor is pointing at, counted in the order shown
+	isEditingValue   bool  // tracks state of field editing
+	confirmingSave   bool  // tracks whether a save awaits confirmation
+	confirmingCancel bool  // tracks whether quitting without saving awaits confirmation
+	showingHelp      bool  // tracks whether the list of keybindings is shown
+	QuitWithCancel   bool  // can be used to communicate whether changes ought be saved
+	saved            bool  // tracks whether the user quit by saving
+	filtering        bool  // tracks whether the user is typing a filter
+	Settings         MenuSettings
+
+	filter  string                  // narrows the fields shown to those with names containing it
+	ctx     context.Context         // cancels the menu once done, if set through WithContext
+	unwatch context.CancelCauseFunc // stops the watch on ctx once the menu quits
+	toast   string                  // short message shown below the menu, until it expires
+	toastID int64                   // number of the toast shown, told apart from older ones on expiry
+
+	// VALIDATION STATE
+	validationErrs map[string]string // problems found on save, keyed by field name
+	submitErr      string            // problem reported by Settings.OnSubmit on save
+	warnings       []string          // problems found while building the menu
+	invalid        bool              // whether a field shown failed its constraints when values last changed
+
+	// DISPLAY STATE
+	width, height int            // size of the terminal, once reported
+	viewport      viewport.Model // scrolls the fields if too tall for the terminal, or Settings.UseViewport is set
 }
 
 // Init initializes the menu settings with default values.
@@ -150,50 +1031,552 @@ func (m *MenuSettings) Init() {
 		NavCursorChar:  "> ",
 		EditCursorChar: ">>",
 		TabAfterEntry:  true,
+		PageSize:       defaultPageSize,
+		Keys:           DefaultKeyMap(),
+		ShowProgress:   true,
+		FooterText:     DefaultFooterText,
+	}
+}
+
+// defaultPageSize is the PageSize used when none is set.
+const defaultPageSize = 10
+
+// pageSize returns the configured PageSize,
+// or the default if it was left unset.
+func (m *MenuSettings) pageSize() int {
+	if m.PageSize <= 0 {
+		return defaultPageSize
+	}
+	return m.PageSize
+}
+
+// finishEdit commits the edit of the field under the cursor and
+// leaves edit mode, reporting whether it did. On a bad value, the
+// menu stays in edit mode so that the user can correct it.
+func (m *TModelStructMenu) finishEdit() bool {
+	f := m.getFieldUnderCursor()
+	if err := f.commitEdit(); err != nil {
+		return false
 	}
+	delete(m.validationErrs, f.name)
+	m.isEditingValue = false
+	return true
+}
+
+// stepField steps the field under the cursor by delta, as users
+// do during navigation. Bool fields shown as selectors flip either
+// way, like fields with options cycling through them.
+func (m *TModelStructMenu) stepField(delta int) {
+	f := m.getFieldUnderCursor()
+	switch {
+	case f.isReadOnly():
+	case f.kind == FieldBool && m.Settings.BoolSelectors:
+		f.b = !f.b
+	default:
+		f.step(delta)
+	}
+}
+
+// moveUp moves the cursor to the field above,
+// which decreases the index the user is focused on
+func (m *TModelStructMenu) moveUp() {
+	m.moveCursor(-1, m.Settings.SkipReadOnlyInNav)
+}
+
+// moveDown moves the cursor to the field below,
+// which increases the index the user is focused on
+func (m *TModelStructMenu) moveDown() {
+	m.moveCursor(1, m.Settings.SkipReadOnlyInNav)
+}
+
+// tabCursor moves the field index the user is focused on
+// as tabbing does, which always passes over read-only fields.
+func (m *TModelStructMenu) tabCursor(step int) {
+	m.moveCursor(step, true)
+}
+
+// jumpCursor moves the cursor straight to the field at index i,
+// clamped to the bounds of the menu. If Settings.SkipReadOnlyInNav
+// is set and that field is read-only, the nearest editable field
+// back toward the cursor is picked instead.
+func (m *TModelStructMenu) jumpCursor(i int) {
+	m.getFieldUnderCursor().errBuf = ""
+	i = max(min(i, len(m.menuFields)-1), 0)
+	step := 1
+	if i > m.cursor {
+		step = -1
+	}
+	for ; i != m.cursor; i += step {
+		if m.isHidden(i) {
+			continue
+		}
+		if !m.Settings.SkipReadOnlyInNav || !m.getFieldAtIndex(i).isReadOnly() {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// moveCursor steps the cursor in the given direction,
+// passing over read-only fields if skipReadOnly is set,
+// and wrapping around the ends of the menu if
+// Settings.WrapNavigation is set. The cursor stays
+// put if there is nowhere to go.
+func (m *TModelStructMenu) moveCursor(step int, skipReadOnly bool) {
+	m.getFieldUnderCursor().errBuf = ""
+	n := len(m.menuFields)
+	// a cursor left past the fields comes back to the nearest one
+	m.cursor = max(min(m.cursor, n-1), 0)
+	for i, tries := m.cursor+step, 1; tries < n; i, tries = i+step, tries+1 {
+		if m.Settings.WrapNavigation {
+			i = (i%n + n) % n
+		} else if i < 0 || i >= n {
+			return
+		}
+		if m.isHidden(i) {
+			continue
+		}
+		if !skipReadOnly || !m.getFieldAtIndex(i).isReadOnly() {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// getFieldAtIndex returns the field at index i
+// of the menu, counted in the order shown.
+func (m *TModelStructMenu) getFieldAtIndex(i int) *menuField {
+	if m.order == nil {
+		return &m.menuFields[i]
+	}
+	return &m.menuFields[m.order[i]]
+}
+
+// getFieldUnderCursor returns the field the cursor is on. Should
+// the cursor be on no field, as in a menu without any, a blank
+// read-only field is returned in its place, so that the menu
+// keeps working rather than panicking.
+func (m *TModelStructMenu) getFieldUnderCursor() *menuField {
+	if !m.cursorInRange() {
+		return &menuField{readOnly: true}
+	}
+	return m.getFieldAtIndex(m.cursor)
+}
+
+// cursorInRange reports whether the cursor is on one of the fields.
+func (m *TModelStructMenu) cursorInRange() bool {
+	return m.cursor >= 0 && m.cursor < len(m.menuFields)
+}
+
+// getFieldByName returns the menu field backed by the struct
+// field of the given name, or nil if no such field is exposed.
+func (m *TModelStructMenu) getFieldByName(name string) *menuField {
+	for i := range m.menuFields {
+		if m.menuFields[i].name == name {
+			return &m.menuFields[i]
+		}
+	}
+	return nil
+}
+
+// Warnings returns the problems found while building the menu
+// that did not keep it from being built, such as struct fields
+// that had to be left unexposed.
+func (m TModelStructMenu) Warnings() []string {
+	return slices.Clone(m.warnings)
+}
+
+// FieldValue returns the current value of the exposed
+// struct field with the given name. The returned bool
+// is false if no such field is exposed by the menu.
+// It may be called while the menu runs, as from the
+// View or Update of a parent model embedding it.
+func (m TModelStructMenu) FieldValue(name string) (any, bool) {
+	f := m.getFieldByName(name)
+	if f == nil {
+		return nil, false
+	}
+	return f.value(), true
+}
+
+// Values returns the current values of all exposed struct fields,
+// keyed by the names of the struct fields (dotted for fields of
+// nested structs) rather than by any smname tag. Like FieldValue,
+// it may be called while the menu runs.
+func (m TModelStructMenu) Values() map[string]any {
+	values := make(map[string]any, len(m.menuFields))
+	for i := range m.menuFields {
+		f := m.getFieldAtIndex(i)
+		values[f.name] = f.value()
+	}
+	return values
+}
+
+// addFields appends a menu field for every exposed field of the
+// struct value v. Nested structs are flattened into fields named
+// by their path (e.g. "Address.City"), and fieldList is matched
+// against those paths. Listing a nested struct applies to all of
+// its fields, which is tracked through listed. The fields of
+// embedded structs are named as they are promoted, without the
+// name of the embedded struct.
+func (m *TModelStructMenu) addFields(v reflect.Value, prefix string, fieldList []string, asBlacklist, listed bool) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		path := prefix + field.Name
+		nested := field.Type.Kind() == reflect.Struct && !supportedType(field.Type)
+		embedded := nested && field.Anonymous
+
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if m.Settings.UseJSONNames && jsonName == "-" {
+			continue
+		}
+
+		inList := listed || slices.Contains(fieldList, path)
+		if len(fieldList) != 0 {
+			if asBlacklist {
+				if inList {
+					continue
+				}
+			} else {
+				// a nested struct may hold whitelisted fields without being listed itself
+				if !inList && !embedded && !(nested && slices.ContainsFunc(fieldList, func(name string) bool {
+					return strings.HasPrefix(name, path+".")
+				})) {
+					continue
+				}
+			}
+		}
+
+		fieldVal := v.Field(i)
+		if embedded {
+			// the promoted fields of an unexported embedded
+			// struct can be set even though it can't be
+			if err := m.addPromotedFields(v, i, prefix, fieldList, asBlacklist, inList); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.Anonymous && !supportedType(field.Type) {
+			m.warnings = append(m.warnings, fmt.Sprintf("field '%s' left unexposed (embedded %v is not a struct)", path, field.Type.Kind()))
+			continue
+		}
+		if !fieldVal.CanSet() {
+			m.warnings = append(m.warnings, fmt.Sprintf("field '%s' left unexposed (cannot be set; unexported or not addressable)", path))
+			continue
+		}
+
+		if nested {
+			n := len(m.menuFields)
+			if err := m.addFields(fieldVal, path+".", fieldList, asBlacklist, inList); err != nil {
+				return err
+			}
+			for j := n; j < len(m.menuFields); j++ {
+				m.menuFields[j].parents = append([]reflect.StructTag{field.Tag}, m.menuFields[j].parents...)
+			}
+			continue
+		}
+
+		// pointers are exposed through the value they point to,
+		// with a nil pointer standing in for the zero value
+		isPtr, isNil := field.Type.Kind() == reflect.Pointer, false
+		if isPtr {
+			if !supportedType(field.Type.Elem()) {
+				m.warnings = append(m.warnings, fmt.Sprintf("field '%s' left unexposed (pointer to unsupported type)", path))
+				continue
+			}
+			isNil = fieldVal.IsNil()
+			field.Type = field.Type.Elem()
+			if isNil {
+				fieldVal = reflect.Zero(field.Type)
+			} else {
+				fieldVal = fieldVal.Elem()
+			}
+		}
+
+		newField, err := newMenuField(field, fieldVal)
+		if err != nil {
+			return err
+		}
+		newField.name = path
+		if m.Settings.UseJSONNames && newField.smName == "" && jsonName != "" {
+			newField.smName = jsonName
+		}
+		newField.ptr, newField.isNil = isPtr, isNil
+		// a nil pointer holds a value from the start only if given a default
+		newField.set = newField.def != nil
+		m.menuFields = append(m.menuFields, newField)
+	}
+	return nil
 }
 
-// incrCursor increases the field index the user is focused on
-func (m *TModelStructMenu) incrCursor() {
-	if m.cursor > 0 {
-		m.getFieldUnderCursor().errBuf = ""
-		m.cursor--
+// addPromotedFields appends menu fields for the fields of the
+// struct embedded as the i-th field of the struct value v, as
+// described by addFields. Fields shadowed by others of the same
+// name, which Go doesn't promote, are left out.
+func (m *TModelStructMenu) addPromotedFields(v reflect.Value, i int, prefix string, fieldList []string, asBlacklist, listed bool) error {
+	n := len(m.menuFields)
+	if err := m.addFields(v.Field(i), prefix, fieldList, asBlacklist, listed); err != nil {
+		return err
 	}
+	kept := slices.DeleteFunc(m.menuFields[n:], func(f menuField) bool {
+		name, _, _ := strings.Cut(strings.TrimPrefix(f.name, prefix), ".")
+		promoted, ok := v.Type().FieldByName(name)
+		return !ok || promoted.Index[0] != i
+	})
+	m.menuFields = m.menuFields[:n+len(kept)]
+	return nil
 }
 
-// decrCursor decreases the field index the user is focused on
-func (m *TModelStructMenu) decrCursor() {
-	m.getFieldUnderCursor().errBuf = ""
-	if m.cursor < len(m.menuFields)-1 {
-		m.cursor++
+// supportedType reports whether values of type t
+// can be exposed to users as a menu field.
+func supportedType(t reflect.Type) bool {
+	if isFormatterType(t) {
+		return true
 	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Struct:
+		return t == timeType
+	case reflect.Slice:
+		return isListType(t)
+	case reflect.Map:
+		return isMapType(t)
+	}
+	return false
 }
 
-func (m *TModelStructMenu) getFieldAtIndex(i int) *menuField {
-	return &m.menuFields[i]
+// parseBoolTag reads a boolean from the given tag of the
+// struct field, returning false if the tag is not present.
+func parseBoolTag(field reflect.StructField, tag string) (bool, error) {
+	tagVal := field.Tag.Get(tag)
+	if tagVal == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(tagVal)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s tag on field '%s': %w", tag, field.Name, err)
+	}
+	return b, nil
 }
 
-func (m *TModelStructMenu) getFieldUnderCursor() *menuField {
-	return m.getFieldAtIndex(m.cursor)
+// newMenuField builds a menu field from a struct field
+// and its value, configured by the tags of the field.
+func newMenuField(field reflect.StructField, fieldVal reflect.Value) (menuField, error) {
+	newField := menuField{}
+	kind := field.Type.Kind()
+	if isFormatterType(field.Type) {
+		// types formatting themselves are handled through
+		// the FieldFormatter interface, whatever their kind
+		kind = reflect.Interface
+	}
+	switch kind {
+	case reflect.Interface:
+		newField.kind = FieldCustom
+		newField.custom = reflect.New(field.Type)
+		newField.custom.Elem().Set(fieldVal)
+	case reflect.Struct:
+		if field.Type != timeType {
+			return menuField{}, fmt.Errorf("could not parse struct")
+		}
+		newField.kind = FieldTime
+		newField.t = fieldVal.Interface().(time.Time)
+		newField.layout = field.Tag.Get("smtimeformat")
+		if newField.layout == "" {
+			newField.layout = time.DateOnly
+		}
+	case reflect.String:
+		newField.kind = FieldString
+		newField.s = fieldVal.String()
+	case reflect.Bool:
+		newField.kind = FieldBool
+		newField.b = fieldVal.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// durations are ints underneath, but are
+		// shown and typed as text, as in "1m30s"
+		if field.Type == durationType {
+			newField.kind = FieldDuration
+			newField.d = time.Duration(fieldVal.Int())
+			break
+		}
+		newField.kind = FieldInt
+		newField.i = fieldVal.Int()
+		newField.bits = field.Type.Bits()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		newField.kind = FieldUint
+		newField.u = fieldVal.Uint()
+		newField.bits = field.Type.Bits()
+	case reflect.Slice:
+		if !isListType(field.Type) {
+			return menuField{}, fmt.Errorf("could not parse struct")
+		}
+		newField.kind = FieldList
+		newField.setValue(fieldVal.Interface())
+	case reflect.Map:
+		if !isMapType(field.Type) {
+			return menuField{}, fmt.Errorf("could not parse struct")
+		}
+		newField.kind = FieldMap
+		newField.setValue(fieldVal.Interface())
+	default:
+		return menuField{}, fmt.Errorf("could not parse struct")
+	}
+	newField.tag = field.Tag
+	newField.smName = field.Tag.Get("smname")
+	newField.smDes = field.Tag.Get("smdes")
+	newField.placeholder = field.Tag.Get("smplaceholder")
+	if pattern := field.Tag.Get("smregex"); pattern != "" && newField.kind == FieldString {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return menuField{}, fmt.Errorf("invalid smregex tag on field '%s': %w", field.Name, err)
+		}
+		newField.regex = re
+	}
+	if options := field.Tag.Get("smoptions"); options != "" && newField.kind == FieldString {
+		for _, option := range strings.Split(options, ",") {
+			newField.options = append(newField.options, strings.TrimSpace(option))
+		}
+	}
+	if labels := field.Tag.Get("smbool"); labels != "" && newField.kind == FieldBool {
+		yes, no, ok := strings.Cut(labels, ",")
+		if !ok || strings.Contains(no, ",") {
+			return menuField{}, fmt.Errorf("invalid smbool tag on field '%s': want two labels, as in \"Yes,No\"", field.Name)
+		}
+		newField.labels = []string{strings.TrimSpace(yes), strings.TrimSpace(no)}
+	}
+	var err error
+	if newField.required, err = parseBoolTag(field, "smrequired"); err != nil {
+		return menuField{}, err
+	}
+	if newField.kind == FieldInt || newField.kind == FieldUint {
+		if newField.minVal, err = parseBoundTag(field, "smmin"); err != nil {
+			return menuField{}, err
+		}
+		if newField.maxVal, err = parseBoundTag(field, "smmax"); err != nil {
+			return menuField{}, err
+		}
+		if newField.percent, err = parseBoolTag(field, "smpercent"); err != nil {
+			return menuField{}, err
+		}
+		if newField.percent {
+			newField.percentBounds()
+		}
+	}
+	if maxLen := field.Tag.Get("smmaxlen"); maxLen != "" && newField.kind == FieldString {
+		n, err := strconv.Atoi(maxLen)
+		if err != nil {
+			return menuField{}, fmt.Errorf("invalid smmaxlen tag on field '%s': %w", field.Name, err)
+		}
+		newField.maxLen = n
+	}
+	if step := field.Tag.Get("smstep"); step != "" && (newField.kind == FieldInt || newField.kind == FieldUint) {
+		n, err := strconv.Atoi(step)
+		if err == nil && n <= 0 {
+			err = errors.New("step must be positive")
+		}
+		if err != nil {
+			return menuField{}, fmt.Errorf("invalid smstep tag on field '%s': %w", field.Name, err)
+		}
+		newField.stepBy = n
+	}
+	if newField.kind == FieldInt || newField.kind == FieldUint {
+		if newField.grouped, err = parseBoolTag(field, "smgroupdigits"); err != nil {
+			return menuField{}, err
+		}
+	}
+	if base := field.Tag.Get("smbase"); base != "" && (newField.kind == FieldInt || newField.kind == FieldUint) {
+		b, err := parseBase(base)
+		if err != nil {
+			return menuField{}, fmt.Errorf("invalid smbase tag on field '%s': %w", field.Name, err)
+		}
+		newField.base = b
+	}
+	if suggest := field.Tag.Get("smsuggest"); suggest != "" && newField.kind == FieldString {
+		for _, suggestion := range strings.Split(suggest, ",") {
+			newField.suggest = append(newField.suggest, strings.TrimSpace(suggestion))
+		}
+	}
+	if newField.kind == FieldString {
+		if newField.mask, err = parseBoolTag(field, "smmask"); err != nil {
+			return menuField{}, err
+		}
+	}
+	if showIf := field.Tag.Get("smshowif"); showIf != "" {
+		c, err := parseCondition(showIf)
+		if err != nil {
+			return menuField{}, fmt.Errorf("invalid smshowif tag on field '%s': %w", field.Name, err)
+		}
+		newField.showIf = c
+	}
+	if path := field.Tag.Get("smpath"); path != "" && newField.kind == FieldString {
+		if !slices.Contains(pathKinds, path) {
+			return menuField{}, fmt.Errorf("invalid smpath tag on field '%s': unknown kind %q (want file, dir or writable)", field.Name, path)
+		}
+		newField.path = path
+	}
+	if newField.kind == FieldString {
+		if newField.multiline, err = parseBoolTag(field, "smmultiline"); err != nil {
+			return menuField{}, err
+		}
+	}
+	if order := field.Tag.Get("smorder"); order != "" {
+		n, err := strconv.Atoi(order)
+		if err != nil {
+			return menuField{}, fmt.Errorf("invalid smorder tag on field '%s': %w", field.Name, err)
+		}
+		newField.order = &n
+	}
+	newField.group = field.Tag.Get("smgroup")
+	if newField.readOnly, err = parseBoolTag(field, "smreadonly"); err != nil {
+		return menuField{}, err
+	}
+
+	// a default stands in for a zero value, but never overrides another
+	if def := field.Tag.Get("smdefault"); def != "" {
+		parsed := newField
+		if err := parsed.setText(def); err != nil {
+			return menuField{}, fmt.Errorf("invalid smdefault tag on field '%s': %w", field.Name, err)
+		}
+		newField.def = parsed.value()
+		if fieldVal.IsZero() {
+			newField.setValue(newField.def)
+		}
+	}
+	// the original value is taken after any default, so
+	// that a form left as it was shown is not changed
+	newField.orig = newField.value()
+	return newField, nil
 }
 
 // InitialTModelStructMenu creates a new struct menu from the given parameters.
 // If customSettings are not provided, the menu will fall back to defaults.
 // If using custom menu settings, first initialize them with the setDefaults() method.
-func InitialTModelStructMenu(structObj any, fieldList []string, asBlacklist bool, customSettings *MenuSettings) (TModelStructMenu, error) {
+// Options may be given to start the menu in a particular state.
+// An error is returned if structObj is not a non-nil pointer to a
+// struct, or exposes no fields, in which case the returned model
+// is not to be used.
+func InitialTModelStructMenu(structObj any, fieldList []string, asBlacklist bool, customSettings *MenuSettings, opts ...MenuOption) (TModelStructMenu, error) {
 	// if fieldList is empty, all fields are exposed to users; otherwise, it is used as a whitelist.
 	// if bool parameter 'asBlacklist' is 'true', the fieldList is used as a blacklist instead of a whitelist.
 	t := reflect.TypeOf(structObj)
 	v := reflect.ValueOf(structObj)
+	if t == nil {
+		return TModelStructMenu{}, errors.New("structObj should be a pointer to struct, got nil")
+	}
 	if t.Kind() == reflect.Pointer {
 		t = t.Elem()
 		v = v.Elem()
 	} else {
-		return TModelStructMenu{}, errors.New("structObj should be a pointer to struct, so as to have addressable fields")
+		return TModelStructMenu{}, fmt.Errorf("structObj should be a pointer to struct, so as to have addressable fields, got %v", t)
 	}
 	if t.Kind() != reflect.Struct {
-		fmt.Println("ERROR: Not a struct. Check your input!")
-		return TModelStructMenu{}, nil
+		return TModelStructMenu{}, fmt.Errorf("structObj should be a pointer to struct, got pointer to %v", t.Kind())
+	}
+	if !v.IsValid() {
+		return TModelStructMenu{}, fmt.Errorf("structObj should be a pointer to struct, got nil %v", reflect.TypeOf(structObj))
 	}
 	newModel := TModelStructMenu{
 		isEditingValue: false,
@@ -207,205 +1590,788 @@ func InitialTModelStructMenu(structObj any, fieldList []string, asBlacklist bool
 		newModel.Settings.Init()
 	}
 
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-
-		if len(fieldList) != 0 {
-			if asBlacklist {
-				if slices.Contains(fieldList, field.Name) {
-					continue
-				}
-			} else {
-				if !(slices.Contains(fieldList, field.Name)) {
-					continue
-				}
-			}
-		}
+	if err := newModel.addFields(v, "", fieldList, asBlacklist, false); err != nil {
+		return TModelStructMenu{}, err
+	}
 
-		fieldVal := v.FieldByName(field.Name)
-		if !fieldVal.CanSet() {
-			fmt.Printf("Warning: Field '%s' left unexposed (cannot be set; unexported or not addressable).\n", field.Name)
-			continue
+	// Fields with an smorder tag come first, in ascending order,
+	// followed by the rest. Ties keep their declaration order.
+	slices.SortStableFunc(newModel.menuFields, func(a, b menuField) int {
+		switch {
+		case a.order != nil && b.order != nil:
+			return cmp.Compare(*a.order, *b.order)
+		case a.order != nil:
+			return -1
+		case b.order != nil:
+			return 1
 		}
+		return 0
+	})
 
-		newField := menuField{}
-		switch field.Type.Kind() {
-		case reflect.String:
-			newField.kind = FieldString
-			newField.s = fieldVal.String()
-		case reflect.Bool:
-			newField.kind = FieldBool
-			newField.b = fieldVal.Bool()
-		case reflect.Int:
-			newField.kind = FieldInt
-			newField.i = int(fieldVal.Int())
-		default:
-			return TModelStructMenu{}, fmt.Errorf("could not parse struct")
+	// Fields of a group are then shown gathered where the first of
+	// them stands, so that each group is listed as one section. This
+	// only changes the order they are shown and navigated in; the
+	// fields themselves keep theirs.
+	groups := map[string]int{}
+	newModel.order = make([]int, len(newModel.menuFields))
+	for i, f := range newModel.menuFields {
+		if _, ok := groups[f.group]; !ok {
+			groups[f.group] = len(groups)
 		}
-		newField.name = field.Name
-		newField.smName = field.Tag.Get("smname")
-		newField.smDes = field.Tag.Get("smdes")
-		newModel.menuFields = append(newModel.menuFields, newField)
+		newModel.order[i] = i
 	}
+	slices.SortStableFunc(newModel.order, func(a, b int) int {
+		return cmp.Compare(groups[newModel.menuFields[a].group], groups[newModel.menuFields[b].group])
+	})
 
 	if len(newModel.menuFields) == 0 {
 		return TModelStructMenu{}, fmt.Errorf("ERROR: No fields to expose to users in struct")
 	}
+	if err := newModel.checkConditions(); err != nil {
+		return TModelStructMenu{}, err
+	}
+
+	// The cursor starts on the first field shown that users can
+	// edit, or on the very first if there is none.
+	for i := range newModel.menuFields {
+		if f := newModel.getFieldAtIndex(i); !f.isReadOnly() && newModel.isShown(f) {
+			newModel.cursor = i
+			break
+		}
+	}
 
+	for _, opt := range opts {
+		opt(&newModel)
+	}
+	newModel.checkValidity()
 	return newModel, nil
 }
 
+// ParseStruct writes the current value of every
+// exposed field into the struct pointed to by obj.
+// Fields that cannot be written don't stop the rest;
+// their errors are joined into the one returned.
+// A string value longer than its smmaxlen tag allows,
+// as may be prefilled by the caller, is not truncated;
+// an error is returned instead.
 func (m TModelStructMenu) ParseStruct(obj any) error {
+	return m.parseInto(obj, false)
+}
+
+// ParseChangedInto writes into the struct pointed to by obj
+// only the fields the user changed from their original values.
+// Fields left untouched in the menu are not written, so changes
+// made to them elsewhere in the meantime are preserved.
+func (m TModelStructMenu) ParseChangedInto(obj any) error {
+	return m.parseInto(obj, true)
+}
+
+func (m TModelStructMenu) parseInto(obj any, onlyChanged bool) error {
 	v := reflect.ValueOf(obj)
 	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
 		return fmt.Errorf("expected a pointer to a struct, got %v", v.Kind())
 	}
 	v = v.Elem()
 
+	// Problems with one field don't keep the others from being
+	// written; all of them are collected and returned together.
+	var errs []error
+	if err := m.recompute(); err != nil {
+		errs = append(errs, err)
+	}
+
 	for _, f := range m.menuFields {
-		field := v.FieldByName(f.name)
+		if onlyChanged && !f.isDirty() {
+			continue
+		}
+		// read-only fields keep whatever value the struct holds
+		if f.readOnly {
+			continue
+		}
+		field := fieldByPath(v, f.name)
+		if f.ptr && field.IsValid() {
+			// leave a nil pointer nil unless it was given a value
+			if f.isUnset() {
+				continue
+			}
+			if field.IsNil() && field.CanSet() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+		}
 
 		if !field.IsValid() {
-			fmt.Printf("Warning: Field '%s' not found in struct.\n", f.name)
+			errs = append(errs, fmt.Errorf("field '%s' not found in struct", f.name))
 			continue
 		}
 		if !field.CanSet() {
-			fmt.Printf("Warning: Field '%s' cannot be set (unexported or not addressable).\n", f.name)
+			errs = append(errs, fmt.Errorf("field '%s' cannot be set (unexported or not addressable)", f.name))
 			continue
 		}
-
-		switch f.kind {
-		case FieldString:
-			field.SetString(f.s)
-		case FieldBool:
-			field.SetBool(f.b)
-		case FieldInt:
-			field.SetInt(int64(f.i))
-		default:
-			return fmt.Errorf("unsupported kind for field '%s': %v", f.name, f.kind)
+		if err := f.writeTo(field); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
+	return errors.Join(errs...)
+}
+
+// writeTo sets the struct field to the value of the menu field.
+func (f *menuField) writeTo(field reflect.Value) error {
+	switch f.kind {
+	case FieldString:
+		// a value prefilled past its limit is refused, not truncated
+		if f.maxLen > 0 && len([]rune(f.s)) > f.maxLen {
+			return fmt.Errorf("value of field '%s' exceeds maximum length of %d", f.name, f.maxLen)
+		}
+		field.SetString(f.s)
+	case FieldBool:
+		field.SetBool(f.b)
+	case FieldInt:
+		if err := f.checkRange(f.i); err != nil {
+			return err
+		}
+		if field.OverflowInt(f.i) {
+			return fmt.Errorf("type mismatch for field '%s': %d overflows %v", f.name, f.i, field.Type())
+		}
+		field.SetInt(f.i)
+	case FieldUint:
+		if err := f.checkRange(uintAsInt64(f.u)); err != nil {
+			return err
+		}
+		if field.OverflowUint(f.u) {
+			return fmt.Errorf("type mismatch for field '%s': %d overflows %v", f.name, f.u, field.Type())
+		}
+		field.SetUint(f.u)
+	case FieldTime:
+		field.Set(reflect.ValueOf(f.t))
+	case FieldDuration:
+		field.SetInt(int64(f.d))
+	case FieldCustom:
+		field.Set(f.custom.Elem())
+	case FieldList:
+		field.Set(reflect.ValueOf(slices.Clone(f.list)).Convert(field.Type()))
+	case FieldMap:
+		field.Set(reflect.ValueOf(f.mapValue()).Convert(field.Type()))
+	default:
+		return fmt.Errorf("unsupported kind for field '%s': %v", f.name, f.kind)
+	}
 	return nil
 }
 
+// fieldByPath returns the field of the struct value v found by
+// following a path of field names, separated by dots for nested
+// structs. The returned value is invalid if there is no such field.
+func fieldByPath(v reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		v = v.FieldByName(name)
+	}
+	return v
+}
+
 func (m TModelStructMenu) Init() tea.Cmd {
-	// Just return `nil`, which means "no I/O right now, please."
-	return nil
+	var cmds []tea.Cmd
+	if m.ctx != nil {
+		cmds = append(cmds, watchContext(m.ctx))
+	}
+	if m.Settings.MouseClicks {
+		cmds = append(cmds, tea.EnableMouseCellMotion)
+	}
+	// A nil command means "no I/O right now, please."
+	return tea.Batch(cmds...)
 }
 
 func (m TModelStructMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	before := make([]any, len(m.menuFields))
+	for i := range m.menuFields {
+		before[i] = m.getFieldAtIndex(i).value()
+	}
+	// the value being typed counts as the value of the field being
+	// edited, so that changes are reported keystroke by keystroke
+	var edited any
+	var editedOK bool
+	if m.isEditingValue && m.Settings.OnChange != nil {
+		edited, editedOK = m.getFieldUnderCursor().editedValue()
+	}
+	model, cmd := m.update(msg)
+	after := model.(TModelStructMenu)
+	changed := false
+	for i := range after.menuFields {
+		if f := after.getFieldAtIndex(i); !sameValue(f.value(), before[i]) {
+			changed = true
+			f.touched = true
+			if m.Settings.OnChange != nil {
+				m.Settings.OnChange(f.name, f.value())
+			}
+		}
+	}
+	if changed {
+		after.checkValidity()
+	}
+	if after.unwatch != nil && (after.saved || after.QuitWithCancel) {
+		after.unwatch(errMenuQuit)
+	}
+	if m.Settings.OnChange == nil || !m.isEditingValue {
+		return after, cmd
+	}
+	f := after.getFieldAtIndex(m.cursor)
+	switch {
+	case !sameValue(f.value(), before[m.cursor]):
+		// already reported above
+	case after.isEditingValue && after.cursor == m.cursor:
+		if v, ok := f.editedValue(); ok && (!editedOK || !sameValue(v, edited)) {
+			m.Settings.OnChange(f.name, v)
+		}
+	case editedOK && !sameValue(f.value(), edited):
+		// the edit was abandoned, taking the field back to its value
+		m.Settings.OnChange(f.name, f.value())
+	}
+	return after, cmd
+}
+
+// update applies the message to the menu on behalf of Update.
+func (m TModelStructMenu) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	cursor, wasEditing := m.cursor, m.isEditingValue
+
 	switch msg := msg.(type) {
+	// Keep track of the terminal size for anything laid out against it.
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+
+	// A toast goes once its time is up, unless a newer one took its place.
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
+
+	// Cancellation from outside is handled like the user's own.
+	case CancelMsg:
+		if !m.saved {
+			m.QuitWithCancel = true
+			return m, tea.Quit
+		}
+
+	// Mouse-wheel scrolling only concerns the viewport.
+	case tea.MouseMsg:
+		if m.viewportActive() {
+			m.viewport, cmd = m.viewport.Update(msg)
+		}
+		if m.Settings.MouseClicks {
+			m.handleClick(msg)
+		}
+
 	// Is it a key press?
 	case tea.KeyMsg:
 
-		// toggle edit mode on field if 'enter' key was pressed
-		if msg.String() == "enter" {
+		// while changes are under review, only a decision is accepted
+		if m.confirmingSave {
+			switch msg.String() {
+			case "y", "enter":
+				m.saved = true
+				return m, tea.Quit
+			case "n", "esc":
+				m.confirmingSave = false
+			case "ctrl+c":
+				m.QuitWithCancel = true
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		// likewise before changes are discarded
+		if m.confirmingCancel {
+			switch msg.String() {
+			case "y", "ctrl+c":
+				m.QuitWithCancel = true
+				return m, tea.Quit
+			case "n", "esc":
+				m.confirmingCancel = false
+			}
+			return m, nil
+		}
+
+		// the help is dismissed before anything else can be done,
+		// short of cancelling with ctrl+c
+		if m.showingHelp {
+			switch {
+			case msg.Type == tea.KeyCtrlC:
+				m.showingHelp = false
+				return m.cancel()
+			case msg.Type == tea.KeyEsc || keyIn(msg, m.Settings.Keys.withDefaults().Help):
+				m.showingHelp = false
+			}
+			return m, nil
+		}
+
+		// a filter being typed takes every key but ctrl+c
+		if m.filtering && msg.Type != tea.KeyCtrlC {
+			m.handleFilterKey(msg)
+			break
+		}
+
+		// give any custom key handling the first say during navigation
+		if !m.isEditingValue && m.Settings.KeyInterceptor != nil {
+			if handled, cmd := m.Settings.KeyInterceptor(msg, &m); handled {
+				m.recompute()
+				return m, cmd
+			}
+		}
+
+		keys := m.Settings.Keys.withDefaults()
+		m.toast = ""
+
+		// toggle edit mode on field if 'enter' (by default) was pressed
+		if keyIn(msg, keys.ToggleEdit) {
 			f := m.getFieldUnderCursor()
 			if !m.isEditingValue {
-				m.isEditingValue = true
-			} else {
-				f.commitEdit()
-				m.isEditingValue = false
-				if m.Settings.TabAfterEntry {
-					m.decrCursor()
+				if !f.isReadOnly() {
+					f.beginEdit()
+					m.isEditingValue = true
+				}
+			} else if f.multiline {
+				// in a text area, enter starts a new line, and
+				// tabbing commits the edit instead
+				f.handleChar("\n")
+			} else if !f.addsListItem() {
+				// on the "+ add" row of a list, the edit goes on with a new
+				// entry instead
+				if m.finishEdit() && m.Settings.TabAfterEntry {
+					m.tabCursor(1)
 				}
 			}
 		} else if msg.Type == tea.KeyBackspace {
 			if m.isEditingValue {
 				m.getFieldUnderCursor().handleBackspace()
 			}
+		} else if msg.Type == tea.KeyEsc && m.isEditingValue {
+			// abandon the edit, restoring the value from before it began
+			m.getFieldUnderCursor().cancelEdit()
+			m.isEditingValue = false
 		} else {
 			if m.isEditingValue {
-				m.getFieldUnderCursor().handleChar(msg.String())
+				switch {
+				// Tabbing accepts a suggestion shown for the value,
+				// or else commits the edit and moves on, as in any form.
+				case keyIn(msg, keys.NextField):
+					if !m.getFieldUnderCursor().acceptSuggestion() && m.finishEdit() {
+						m.tabCursor(1)
+					}
+				case keyIn(msg, keys.PrevField):
+					if m.finishEdit() {
+						m.tabCursor(-1)
+					}
+				case keyIn(msg, keys.ClearField):
+					m.getFieldUnderCursor().clearEdit()
+				case keyIn(msg, keys.DeleteWord):
+					m.getFieldUnderCursor().deleteWord()
+				case msg.Type == tea.KeySpace:
+					// a space is typed as such however the terminal
+					// reports it, and never toggles anything mid-edit
+					m.getFieldUnderCursor().handleChar(" ")
+				case msg.Type == tea.KeyRunes:
+					// pasted text comes as one run of runes, which
+					// String would wrap in brackets
+					m.getFieldUnderCursor().insertText(msg.Runes)
+				default:
+					// other keys are passed on by name, as in "left",
+					// if they mean anything mid-edit at all
+					if slices.Contains(editKeys, msg.String()) {
+						m.getFieldUnderCursor().handleChar(msg.String())
+					}
+				}
 			} else {
 				// Cool, what was the actual key pressed?
-				switch msg.String() {
+				switch {
 
-				case "s":
+				case keyIn(msg, keys.Save):
+					if m.saveBlocked() {
+						// no save, but point out what holds it back
+						m.validate()
+						break
+					}
+					if !m.validate() {
+						// errors are shown beneath the rows they concern
+						break
+					}
+					if m.Settings.ShowDiffOnSave && m.IsDirty() {
+						m.confirmingSave = true
+						return m, nil
+					}
+					m.saved = true
 					return m, tea.Quit
 
 				// These keys should exit the program.
-				case "ctrl+c", "q":
-					m.QuitWithCancel = true
-					return m, tea.Quit
+				case keyIn(msg, keys.Cancel):
+					return m.cancel()
+
+				// Escape clears a filter, or may be set to exit the same way.
+				case msg.Type == tea.KeyEsc:
+					if m.filter != "" {
+						m.filter = ""
+					} else if m.Settings.QuitOnEscape {
+						return m.cancel()
+					}
+
+				// Copy the value under the cursor.
+				case keyIn(msg, keys.Copy):
+					cmd = m.copyField()
 
-				// The "up" and "k" keys move the cursor up, or users may tab backward.
-				case "up", "k", "shift+tab":
-					m.incrCursor()
+				// List the keybindings.
+				case keyIn(msg, keys.Help):
+					m.showingHelp = true
 
-				// The "down" and "j" keys move the cursor down, or users may tab forward.
-				case "down", "j", "tab":
-					m.decrCursor()
+				// Narrow the fields shown to those matching a filter.
+				case keyIn(msg, keys.Filter):
+					m.filtering = true
+
+				// Move the cursor up.
+				case keyIn(msg, keys.Up):
+					m.moveUp()
+
+				// Move the cursor down.
+				case keyIn(msg, keys.Down):
+					m.moveDown()
+
+				// Move the cursor between columns of fields.
+				case keyIn(msg, keys.PrevColumn):
+					m.moveColumn(-1)
+				case keyIn(msg, keys.NextColumn):
+					m.moveColumn(1)
+
+				// Users may jump to either end of the menu, or by a page of fields.
+				case keyIn(msg, keys.First):
+					m.jumpCursor(0)
+				case keyIn(msg, keys.Last):
+					m.jumpCursor(len(m.menuFields) - 1)
+				case keyIn(msg, keys.PageUp):
+					m.jumpCursor(m.cursor - m.Settings.pageSize())
+				case keyIn(msg, keys.PageDown):
+					m.jumpCursor(m.cursor + m.Settings.pageSize())
+
+				// Users may also tab back and forth between editable fields.
+				case keyIn(msg, keys.PrevField):
+					m.tabCursor(-1)
+				case keyIn(msg, keys.NextField):
+					m.tabCursor(1)
+
+				// Step numeric and option fields down and up.
+				case keyIn(msg, keys.Decrease):
+					m.stepField(-1)
+				case keyIn(msg, keys.Increase):
+					m.stepField(1)
+
+				// Restore the value the field held when the menu was created.
+				case keyIn(msg, keys.ResetField):
+					if f := m.getFieldUnderCursor(); !f.isReadOnly() {
+						f.reset()
+						delete(m.validationErrs, f.name)
+					}
+
+				// Start over with every field as it was when the menu was created.
+				case keyIn(msg, keys.ResetAll):
+					m.ResetAll()
+
+				// Flip a bool field without entering edit mode.
+				case keyIn(msg, keys.ToggleBool):
+					if f := m.getFieldUnderCursor(); f.kind == FieldBool && !f.isReadOnly() {
+						f.b = !f.b
+						if m.Settings.TabAfterEntry {
+							m.tabCursor(1)
+						}
+					}
 
 				}
 			}
 		}
 	}
 
+	// Keep computed fields in step with whatever changed above,
+	// and the cursor off any field that change has hidden.
+	m.recompute()
+	m.keepCursorShown()
+
+	// Feedback from the field is shown for a moment.
+	if f := m.getFieldUnderCursor(); f.notice != "" {
+		cmd = tea.Batch(cmd, m.Toast(f.notice))
+		f.notice = ""
+	}
+
+	// Check a field as soon as users are done with it, if asked to.
+	if m.Settings.ValidateOnBlur && cursor < len(m.menuFields) && m.isShown(m.getFieldAtIndex(cursor)) &&
+		(m.cursor != cursor || wasEditing && !m.isEditingValue) {
+		m.validateField(m.getFieldAtIndex(cursor))
+	}
+	m.syncViewport(msg)
+
 	// Return the updated TModelStructMenu to the Bubble Tea runtime for processing.
-	// Note that we're not returning a command.
-	return m, nil
+	return m, cmd
+}
+
+// cancel quits without saving, or first asks users to confirm
+// as much if Settings.ConfirmOnCancel is set.
+func (m TModelStructMenu) cancel() (tea.Model, tea.Cmd) {
+	if m.Settings.ConfirmOnCancel {
+		m.confirmingCancel = true
+		return m, nil
+	}
+	m.QuitWithCancel = true
+	return m, tea.Quit
 }
 
 func (m TModelStructMenu) View() string {
+	s := m.headerView()
+	if m.confirmingCancel {
+		s += "Discard changes? y/n\n"
+		return s
+	}
+	if m.showingHelp {
+		return s + m.helpView()
+	}
+	if m.confirmingSave {
+		s += "Save the following changes?\n\n" + m.DiffView()
+		s += "\nPress y to save, or n to keep editing.\n"
+		return s
+	}
+
+	if m.viewportActive() {
+		s += m.scrolledFieldsView()
+	} else {
+		s += m.fieldsView()
+	}
+	s += m.footerView()
+
+	// Send the UI for rendering
+	return s
+}
+
+// headerView renders everything above the list of fields.
+func (m TModelStructMenu) headerView() string {
 	var s string
 	// Add the header, if it exists
 	if m.Settings.Header != "" {
-		s = m.Settings.Header + "\n"
+		s = render(m.Settings.Styles.Header, m.Settings.Header) + "\n"
 	}
 	s += "\n"
+	return s
+}
 
-	// for formatting, get longest field name
-	maxFieldName := 0
-	for _, field := range m.menuFields {
-		if fieldName := field.getFieldName(); len(fieldName) > maxFieldName {
-			maxFieldName = len(fieldName)
-		}
-	}
+// fieldsView renders the list of fields, one row per field, laid
+// out in as many columns as Settings.Columns asks for and fit.
+func (m TModelStructMenu) fieldsView() string {
+	var s string
 
-	// for formatting, get longest cursor string and build
-	// the empty version of the cursor based on its length
-	cursorEmpty := ""
-	for _, cursor := range []string{m.Settings.NavCursorChar, m.Settings.EditCursorChar} {
-		if len(cursor) > len(cursorEmpty) {
-			cursorEmpty = ""
-			for range cursor {
-				cursorEmpty += " "
+	cols := m.columns()
+	width := 0
+	if cols > 1 {
+		width = m.columnWidth()
+	}
+	for _, section := range m.sections() {
+		s += m.groupHeader(section[0])
+		rows := (len(section) + cols - 1) / cols
+		for r := range rows {
+			var cells []string
+			for c := range cols {
+				if k := c*rows + r; k < len(section) {
+					cells = append(cells, m.fieldBlock(section[k]))
+				}
 			}
+			if len(cells) == 1 {
+				s += cells[0]
+				continue
+			}
+			// the last column needs no padding to line up
+			for c := range cells {
+				cells[c] = strings.TrimSuffix(cells[c], "\n")
+				if c < len(cells)-1 {
+					cells[c] = padBlock(cells[c], width)
+				}
+			}
+			s += lipgloss.JoinHorizontal(lipgloss.Top, cells...) + "\n"
 		}
 	}
 
-	// Iterate over our fields
-	for i, f := range m.menuFields {
+	return s
+}
+
+// fieldBlock renders the row of the field at index i, followed
+// by its inline description and error, if any.
+func (m TModelStructMenu) fieldBlock(i int) string {
+	f := m.getFieldAtIndex(i)
 
-		// Is the cursor pointing at this choice?
-		cursor := "  " // no cursor
-		if m.cursor == i {
-			if m.isEditingValue {
-				cursor = m.Settings.EditCursorChar
-			} else {
-				cursor = m.Settings.NavCursorChar
-			}
+	// for formatting, get the widest cursor string and build
+	// the empty version of the cursor based on its width
+	cursorWidth := max(lipgloss.Width(m.Settings.NavCursorChar), lipgloss.Width(m.Settings.EditCursorChar))
+	cursorEmpty := strings.Repeat(" ", cursorWidth)
+
+	// Is the cursor pointing at this choice?
+	cursor := cursorEmpty // no cursor
+	if m.cursor == i {
+		if m.isEditingValue {
+			cursor = padRight(m.Settings.EditCursorChar, cursorWidth)
+		} else {
+			cursor = padRight(m.Settings.NavCursorChar, cursorWidth)
 		}
+		cursor = render(m.Settings.Styles.Cursor, cursor)
+	}
 
-		// string represenation of field value
-		value := f.render(m.isEditingValue && m.cursor == i, m.Settings.IBeamChar)
-		s += fmt.Sprintf("%s ⟦ %-*s ⟧: %s\n", cursor, maxFieldName, f.getFieldName(), value)
+	// the row under the cursor takes the focused style in
+	// place of the label and value styles, if one is set
+	labelStyle, valueStyle := m.Settings.Styles.Label, m.Settings.Styles.Value
+	focused := m.cursor == i && m.Settings.Styles.FocusedRow != nil
+	if focused {
+		labelStyle, valueStyle = nil, nil
+	} else if _, ok := m.validationErrs[f.name]; ok {
+		// fields failing validation stand out by their names
+		errStyle := styleOr(m.Settings.Styles.Error, errorStyle)
+		labelStyle = &errStyle
 	}
 
-	// The footer
-	s += "\n"
-	if smDes := m.getFieldAtIndex(m.cursor).smDes; smDes != "" {
-		s += smDes
+	// string represenation of field value
+	value := f.render(m.isEditingValue && m.cursor == i, &m.Settings)
+	if m.cursor != i && f.showsEmptyMarker(&m.Settings) {
+		value = styleOr(m.Settings.Styles.Placeholder, placeholderStyle).Render(m.Settings.EmptyMarker)
+	} else if f.isReadOnly() {
+		value = styleOr(m.Settings.Styles.ReadOnly, readOnlyStyle).Render(value)
+	} else {
+		value = render(valueStyle, value)
+	}
+	// mark fields changed since the menu was created
+	modified := " "
+	if f.isDirty() {
+		modified = "*"
+	}
+	label := render(labelStyle, padRight(f.getFieldName(), m.labelWidth()))
+	row := fmt.Sprintf("⟦ %s ⟧%s: %s", label, modified, value)
+	if focused {
+		row = render(m.Settings.Styles.FocusedRow, row)
+	}
+	row = cursor + " " + row
+	// the lines of a text area below the first line up with it
+	row = strings.ReplaceAll(row, "\n", "\n"+strings.Repeat(" ", m.valueColumn()))
+	return row + "\n" + m.inlineDescription(i) + m.inlineError(i)
+}
+
+// labelWidth returns the width of the widest field name, measured
+// in terminal cells so that wide characters (e.g. CJK) line up.
+func (m TModelStructMenu) labelWidth() int {
+	width := 0
+	for _, field := range m.menuFields {
+		width = max(width, lipgloss.Width(field.getFieldName()))
+	}
+	return width
+}
+
+// padRight pads s with spaces up to the given width in terminal
+// cells, which wide characters take two of.
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
+
+// inlineDescription renders the description shown beneath the row
+// of the field at index i, if Settings.InlineDescriptions is set.
+func (m TModelStructMenu) inlineDescription(i int) string {
+	smDes := m.getFieldAtIndex(i).smDes
+	if !m.Settings.InlineDescriptions || smDes == "" {
+		return ""
+	}
+	return "     " + styleOr(m.Settings.Styles.Description, descriptionStyle).Render(smDes) + "\n"
+}
+
+// inlineError renders the problem found with the field at
+// index i when it was last validated, if any, beneath its row.
+func (m TModelStructMenu) inlineError(i int) string {
+	msg, ok := m.validationErrs[m.getFieldAtIndex(i).name]
+	if !ok {
+		return ""
+	}
+	return "     " + styleOr(m.Settings.Styles.Error, errorStyle).Render("ERROR: "+msg) + "\n"
+}
+
+// groupHeader renders the heading of the section starting at
+// the field at index i, if one does. Fields without a group
+// are set apart from the section before them by a blank line.
+func (m TModelStructMenu) groupHeader(i int) string {
+	// fields hidden by the filter don't count
+	prev := i - 1
+	for prev >= 0 && m.isHidden(prev) {
+		prev--
+	}
+
+	group := m.getFieldAtIndex(i).group
+	if prev >= 0 && m.getFieldAtIndex(prev).group == group || prev < 0 && group == "" {
+		return ""
+	}
+
+	var s string
+	if prev >= 0 {
+		s = "\n"
+	}
+	if group != "" {
+		s += styleOr(m.Settings.Styles.Group, groupStyle).Render(group) + "\n"
+	}
+	return s
+}
+
+// footerView renders everything below the list of fields.
+func (m TModelStructMenu) footerView() string {
+	s := "\n"
+	if smDes := m.getFieldUnderCursor().smDes; smDes != "" && !m.Settings.InlineDescriptions {
+		s += render(m.Settings.Styles.Description, smDes)
 	}
 	s += "\n"
+	if re := m.getFieldUnderCursor().regex; re != nil {
+		s += fmt.Sprintf("Pattern: %s\n", re)
+	}
+	if hint := m.getFieldUnderCursor().rangeHint(); hint != "" {
+		s += fmt.Sprintf("Range: %s\n", hint)
+	}
+	if f := m.getFieldUnderCursor(); f.maxLen > 0 {
+		value := f.s
+		if m.isEditingValue {
+			value = f.editBuf
+		}
+		s += fmt.Sprintf("Length: %d/%d\n", len([]rune(value)), f.maxLen)
+	}
+	if f := m.getFieldUnderCursor(); m.isEditingValue && f.multiline {
+		if next := m.Settings.Keys.withDefaults().NextField; len(next) > 0 {
+			s += fmt.Sprintf("Enter starts a new line; %s finishes.\n", keyNames(next)[0])
+		}
+	}
 
-	s += "\nPress s to save and quit.\nPress q to quit without saving.\n"
+	s += "\n"
+	if m.filtering {
+		s += fmt.Sprintf("Filter: %s%s\n", m.filter, m.Settings.IBeamChar)
+	} else if m.filter != "" {
+		s += fmt.Sprintf("Filter: %s (esc to clear)\n", m.filter)
+	}
+	if m.Settings.ShowProgress && m.cursorInRange() {
+		// only the fields shown are counted, as the filter
+		// and smshowif tags leave them
+		pos, shown := 0, 0
+		for i := range m.menuFields {
+			if m.isHidden(i) {
+				continue
+			}
+			shown++
+			if i <= m.cursor {
+				pos = shown
+			}
+		}
+		s += render(m.Settings.Styles.Footer, fmt.Sprintf("Field %d / %d", pos, shown)) + "\n"
+	}
+	s += m.footerHints()
+	if m.toast != "" {
+		s += render(m.Settings.Styles.Footer, m.toast) + "\n"
+	}
 	if f := m.getFieldUnderCursor(); f.errBuf != "" {
-		s += fmt.Sprintf("ERROR: %s\n", f.errBuf)
+		s += styleOr(m.Settings.Styles.Error, errorStyle).Render("ERROR: "+f.errBuf) + "\n"
+	}
+	if m.submitErr != "" {
+		s += styleOr(m.Settings.Styles.Error, errorStyle).Render("ERROR: "+m.submitErr) + "\n"
 	}
-
-	// Send the UI for rendering
 	return s
 }
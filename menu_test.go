@@ -0,0 +1,333 @@
+package gostructui
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestMenu creates a menu over obj with default settings,
+// failing the test if it can't be created.
+func newTestMenu(t *testing.T, obj any, opts ...MenuOption) TModelStructMenu {
+	t.Helper()
+	m, err := InitialTModelStructMenu(obj, nil, false, nil, opts...)
+	if err != nil {
+		t.Fatalf("InitialTModelStructMenu: %v", err)
+	}
+	return m
+}
+
+func TestIntLimits(t *testing.T) {
+	for _, tt := range []struct {
+		bits     int
+		min, max int64
+	}{
+		{8, math.MinInt8, math.MaxInt8},
+		{16, math.MinInt16, math.MaxInt16},
+		{32, math.MinInt32, math.MaxInt32},
+		{64, math.MinInt64, math.MaxInt64},
+	} {
+		f := menuField{kind: FieldInt, bits: tt.bits}
+		if lo, hi := f.intLimits(); lo != tt.min || hi != tt.max {
+			t.Errorf("intLimits() for %d bits = %d, %d; want %d, %d", tt.bits, lo, hi, tt.min, tt.max)
+		}
+	}
+}
+
+func TestStepStopsAtWidthOfInt(t *testing.T) {
+	obj := struct{ Priority int8 }{Priority: math.MaxInt8 - 1}
+	m := SendKeys(newTestMenu(t, &obj), "right", "right", "right")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Priority != math.MaxInt8 {
+		t.Errorf("Priority = %d, want %d", obj.Priority, math.MaxInt8)
+	}
+}
+
+func TestTypingPastWidthOfIntIsRefused(t *testing.T) {
+	obj := struct{ Priority int8 }{}
+	m := SendKeys(newTestMenu(t, &obj), "enter", "2", "0", "0", "enter")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Priority != 20 {
+		t.Errorf("Priority = %d, want 20", obj.Priority)
+	}
+}
+
+func TestInt64RoundTrips(t *testing.T) {
+	obj := struct{ Big, Small int64 }{Big: math.MaxInt64, Small: math.MinInt64}
+	m := newTestMenu(t, &obj)
+	var out struct{ Big, Small int64 }
+	if err := m.ParseStruct(&out); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if out != obj {
+		t.Errorf("ParseStruct wrote %+v, want %+v", out, obj)
+	}
+}
+
+func TestSpaceTogglesBoolAndAdvances(t *testing.T) {
+	obj := struct {
+		Admin bool
+		Name  string
+	}{}
+	m := SendKeys(newTestMenu(t, &obj), " ")
+	if m.cursor != 1 {
+		t.Errorf("space left the cursor on %d, want 1", m.cursor)
+	}
+	if m.isEditingValue {
+		t.Error("space entered edit mode")
+	}
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if !obj.Admin {
+		t.Error("space didn't toggle the bool")
+	}
+
+	// space does nothing to a field other than a bool
+	if m = SendKeys(m, " "); m.cursor != 1 || m.isEditingValue || m.getFieldUnderCursor().s != "" {
+		t.Errorf("space on a string field moved the cursor to %d or began an edit", m.cursor)
+	}
+}
+
+func TestSpaceTogglesWithoutAdvancing(t *testing.T) {
+	obj := struct {
+		Admin bool
+		Name  string
+	}{}
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.TabAfterEntry = false
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m = SendKeys(m, " ", " "); m.cursor != 0 || m.IsDirty() {
+		t.Errorf("toggling twice left the cursor on %d and the form dirty: %v", m.cursor, m.IsDirty())
+	}
+}
+
+// portAfter returns the value of the Port field of a menu over a
+// struct holding only that field, starting from port, after the
+// given keys are sent.
+func portAfter(t *testing.T, port int, keys ...string) int {
+	t.Helper()
+	obj := struct{ Port int }{Port: port}
+	m := SendKeys(newTestMenu(t, &obj), keys...)
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	return obj.Port
+}
+
+func TestIntEditStartsFromEmptyBuffer(t *testing.T) {
+	for _, tt := range []struct {
+		from int
+		keys []string
+		want int
+	}{
+		{7, []string{"enter", "1", "0", "0", "enter"}, 100},
+		{0, []string{"enter", "1", "0", "0", "enter"}, 100},
+		{7, []string{"enter", "enter"}, 0},
+		{7, []string{"enter", "1", "esc"}, 7},
+	} {
+		if got := portAfter(t, tt.from, tt.keys...); got != tt.want {
+			t.Errorf("keys %q on %d gave %d, want %d", tt.keys, tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestKeyInterceptorHandlesCustomKey(t *testing.T) {
+	obj := struct{ Name, Email string }{}
+	settings := &MenuSettings{}
+	settings.Init()
+	var seen []string
+	settings.KeyInterceptor = func(msg tea.KeyMsg, m *TModelStructMenu) (bool, tea.Cmd) {
+		seen = append(seen, msg.String())
+		if msg.String() == "f2" {
+			m.jumpCursor(1)
+			return true, tea.Quit
+		}
+		return false, nil
+	}
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyF2})
+	m = updated.(TModelStructMenu)
+	if m.cursor != 1 {
+		t.Errorf("interceptor left the cursor on %d, want 1", m.cursor)
+	}
+	if cmd == nil {
+		t.Error("command returned by the interceptor was dropped")
+	}
+
+	// keys left unhandled get their default handling
+	if m = SendKeys(m, "up"); m.cursor != 0 {
+		t.Errorf("up left the cursor on %d, want 0", m.cursor)
+	}
+	// keys typed mid-edit are not intercepted
+	SendKeys(m, "enter", "x")
+	if want := []string{"f2", "up", "enter"}; !slices.Equal(seen, want) {
+		t.Errorf("interceptor saw %q, want %q", seen, want)
+	}
+}
+
+func TestHammeringDigitsStaysInRange(t *testing.T) {
+	nines := []string{"enter"}
+	for range 20 {
+		nines = append(nines, "9")
+	}
+	nines = append(nines, "enter")
+
+	var narrow struct{ N int16 }
+	m := SendKeys(newTestMenu(t, &narrow), nines...)
+	if err := m.ParseStruct(&narrow); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if narrow.N != 9999 {
+		t.Errorf("int16 = %d, want 9999", narrow.N)
+	}
+
+	var wide struct{ N int64 }
+	m = SendKeys(newTestMenu(t, &wide), nines...)
+	if err := m.ParseStruct(&wide); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if wide.N != 999999999999999999 {
+		t.Errorf("int64 = %d, want 999999999999999999", wide.N)
+	}
+
+	var bounded struct {
+		N int `smmax:"500"`
+	}
+	m = SendKeys(newTestMenu(t, &bounded), nines...)
+	if err := m.ParseStruct(&bounded); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if bounded.N != 500 {
+		t.Errorf("bounded int = %d, want 500", bounded.N)
+	}
+}
+
+func TestBackspaceOnNegativeInt(t *testing.T) {
+	for _, tt := range []struct {
+		typed      []string
+		backspaces int
+		want       int
+	}{
+		{[]string{"-", "1"}, 1, 0},
+		{[]string{"-", "1", "0"}, 1, -1},
+		{[]string{"-", "1", "0", "0"}, 1, -10},
+		{[]string{"-", "1", "0", "0"}, 2, -1},
+		{[]string{"-", "1", "0", "0"}, 3, 0},
+		{[]string{"-", "4", "2"}, 1, -4},
+		{[]string{"7"}, 1, 0},
+		{[]string{"-", "7"}, 2, 0},
+	} {
+		keys := append([]string{"enter"}, tt.typed...)
+		for range tt.backspaces {
+			keys = append(keys, "backspace")
+		}
+		keys = append(keys, "enter")
+		if got := portAfter(t, 5, keys...); got != tt.want {
+			t.Errorf("keys %q gave %d, want %d", keys, got, tt.want)
+		}
+	}
+}
+
+func TestBackspaceKeepsSignWhileDigitsRemain(t *testing.T) {
+	var obj struct{ Port int }
+	m := SendKeys(newTestMenu(t, &obj), "enter", "-", "1", "0", "0", "backspace")
+	if f := m.getFieldUnderCursor(); f.editBuf != "-10" {
+		t.Errorf("buffer = %q, want %q", f.editBuf, "-10")
+	}
+	m = SendKeys(m, "backspace", "backspace")
+	if f := m.getFieldUnderCursor(); f.editBuf != "" {
+		t.Errorf("buffer = %q once every digit is gone, want it empty", f.editBuf)
+	}
+}
+
+func TestViewOfMenuWithoutFields(t *testing.T) {
+	var m TModelStructMenu
+	m.Settings.Init()
+	if view := m.View(); view == "" {
+		t.Error("View of a menu without fields is empty")
+	}
+	for _, key := range []string{"down", "up", "enter", "tab", "x", "enter", "end", "r", "y"} {
+		m = SendKeys(m, key)
+		m.View()
+	}
+
+	// a cursor left past the fields is safe as well
+	obj := struct{ Name string }{}
+	m = newTestMenu(t, &obj)
+	m.cursor = 5
+	m.View()
+	SendKeys(m, "enter", "down")
+}
+
+func TestInitialMenuRefusesNonStructs(t *testing.T) {
+	var n int
+	var list []string
+	var nilStruct *struct{ Name string }
+	for name, obj := range map[string]any{
+		"*int":       &n,
+		"*[]string":  &list,
+		"plain":      struct{ Name string }{},
+		"nil":        nil,
+		"nil struct": nilStruct,
+	} {
+		if _, err := InitialTModelStructMenu(obj, nil, false, nil); err == nil {
+			t.Errorf("%s: InitialTModelStructMenu returned no error", name)
+		}
+	}
+}
+
+func TestInvalidBoolTagIsRefused(t *testing.T) {
+	obj := struct {
+		Name string `smreadonly:"maybe"`
+	}{}
+	_, err := InitialTModelStructMenu(&obj, nil, false, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid smreadonly tag on field 'Name'") {
+		t.Errorf("InitialTModelStructMenu returned %v, want an invalid smreadonly tag error", err)
+	}
+}
+
+func TestCtrlCQuitsAtDiscardPrompt(t *testing.T) {
+	obj := struct{ Name string }{}
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.ConfirmOnCancel = true
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m = SendKeys(m, "q"); !m.confirmingCancel {
+		t.Fatal("cancelling didn't ask to discard the changes")
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if m = updated.(TModelStructMenu); !m.QuitWithCancel || cmd == nil {
+		t.Error("ctrl+c at the discard prompt didn't quit")
+	}
+}
+
+func TestCtrlCCancelsWithHelpShown(t *testing.T) {
+	obj := struct{ Name string }{}
+	m := SendKeys(newTestMenu(t, &obj), "?")
+	if !m.showingHelp {
+		t.Fatal("? didn't show the help")
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if m = updated.(TModelStructMenu); !m.QuitWithCancel || cmd == nil {
+		t.Error("ctrl+c with the help shown didn't cancel")
+	}
+}
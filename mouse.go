@@ -0,0 +1,66 @@
+package gostructui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// handleClick focuses the field whose row was clicked, and flips
+// a bool field if its checkbox was. Clicks anywhere else, or made
+// while a field is being edited or a prompt is shown, do nothing.
+func (m *TModelStructMenu) handleClick(msg tea.MouseMsg) {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft ||
+		m.isEditingValue || m.confirmingSave || m.confirmingCancel || m.showingHelp || m.filtering {
+		return
+	}
+
+	line := msg.Y - strings.Count(m.headerView(), "\n")
+	if m.viewportActive() {
+		// the fields are framed by a line for the scroll indicator
+		// above them, unless they all fit
+		if !m.viewport.AtTop() || !m.viewport.AtBottom() {
+			line--
+		}
+		if line < 0 || line >= m.viewport.Height {
+			return
+		}
+		line += m.viewport.YOffset
+	}
+	// in columns, clicks are placed within the column clicked
+	col, x := 0, msg.X
+	if m.columns() > 1 {
+		width := m.columnWidth()
+		col, x = msg.X/width, msg.X%width
+	}
+	i, ok := m.fieldAt(line, col)
+	if !ok {
+		return
+	}
+
+	m.jumpCursor(i)
+	if f := m.getFieldUnderCursor(); m.cursor == i && f.kind == FieldBool && !f.isReadOnly() && x >= m.valueColumn() {
+		f.b = !f.b
+	}
+}
+
+// fieldAt returns the index of the field whose row is on the
+// given line of the fields view, in the given column. The returned
+// bool is false if there is no such row, as for the heading of a
+// group.
+func (m TModelStructMenu) fieldAt(line, col int) (int, bool) {
+	for i, pos := range m.layout() {
+		if pos.shown && pos.line == line && pos.col == col {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// valueColumn returns the column the values of fields start at,
+// past the cursor and the names of the fields.
+func (m TModelStructMenu) valueColumn() int {
+	cursorWidth := max(lipgloss.Width(m.Settings.NavCursorChar), lipgloss.Width(m.Settings.EditCursorChar))
+	return cursorWidth + lipgloss.Width(" ⟦ ") + m.labelWidth() + lipgloss.Width(" ⟧*: ")
+}
@@ -0,0 +1,126 @@
+package gostructui
+
+import (
+	"strings"
+	"testing"
+)
+
+type alternatingForm struct {
+	A string
+	B string `smreadonly:"true"`
+	C string
+	D string `smreadonly:"true"`
+	E string
+}
+
+func TestTabSkipsReadOnlyFields(t *testing.T) {
+	var obj alternatingForm
+	for _, tt := range []struct {
+		keys []string
+		want int
+	}{
+		{nil, 0},
+		{[]string{"tab"}, 2},
+		{[]string{"tab", "tab"}, 4},
+		{[]string{"tab", "tab", "tab"}, 4},
+		{[]string{"tab", "tab", "shift+tab"}, 2},
+		{[]string{"down"}, 1},
+		{[]string{"down", "tab"}, 2},
+		{[]string{"down", "down", "down", "shift+tab"}, 2},
+	} {
+		m := SendKeys(newTestMenu(t, &obj), tt.keys...)
+		if m.cursor != tt.want {
+			t.Errorf("keys %q left the cursor on %d, want %d", tt.keys, m.cursor, tt.want)
+		}
+	}
+}
+
+func TestTabWrapsPastReadOnlyFields(t *testing.T) {
+	var obj alternatingForm
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.WrapNavigation = true
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m = SendKeys(m, "tab", "tab", "tab"); m.cursor != 0 {
+		t.Errorf("tabbing past the last field left the cursor on %d, want 0", m.cursor)
+	}
+	if m = SendKeys(m, "shift+tab"); m.cursor != 4 {
+		t.Errorf("tabbing back past the first field left the cursor on %d, want 4", m.cursor)
+	}
+}
+
+func TestTabStaysWhenEveryOtherFieldIsReadOnly(t *testing.T) {
+	obj := struct {
+		A string `smreadonly:"true"`
+		B string `smreadonly:"true"`
+	}{}
+	m := SendKeys(newTestMenu(t, &obj), "tab")
+	if m.cursor != 0 {
+		t.Errorf("tab moved the cursor to read-only field %d", m.cursor)
+	}
+	if m = SendKeys(m, "down"); m.cursor != 1 {
+		t.Errorf("down left the cursor on %d, want 1", m.cursor)
+	}
+}
+
+func TestSkipReadOnlyInNav(t *testing.T) {
+	var obj alternatingForm
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.SkipReadOnlyInNav = true
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		key  string
+		want int
+	}{
+		{"down", 2},
+		{"down", 4},
+		{"down", 4},
+		{"up", 2},
+		{"up", 0},
+		{"end", 4},
+		{"home", 0},
+	} {
+		if m = SendKeys(m, tt.key); m.cursor != tt.want {
+			t.Errorf("%s left the cursor on %d, want %d", tt.key, m.cursor, tt.want)
+		}
+	}
+}
+
+// cursorRow returns the line of the rendered view s
+// holding the navigation cursor.
+func cursorRow(s, cursor string) int {
+	for i, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(line, cursor) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestDownMovesCursorDownInView(t *testing.T) {
+	var obj alternatingForm
+	m := newTestMenu(t, &obj)
+	cursor := m.Settings.NavCursorChar
+	row := cursorRow(m.View(), cursor)
+	if row < 0 {
+		t.Fatalf("View shows no cursor:\n%s", m.View())
+	}
+	for range 4 {
+		m = SendKeys(m, "down")
+		next := cursorRow(m.View(), cursor)
+		if next != row+1 {
+			t.Fatalf("down moved the cursor from line %d to %d:\n%s", row, next, m.View())
+		}
+		row = next
+	}
+	if m = SendKeys(m, "up"); cursorRow(m.View(), cursor) != row-1 {
+		t.Errorf("up didn't move the cursor up a line:\n%s", m.View())
+	}
+}
@@ -0,0 +1,63 @@
+package gostructui
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// recordChanges returns settings whose OnChange appends each
+// change, as "name=value", to the returned slice.
+func recordChanges() (*MenuSettings, *[]string) {
+	var changes []string
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.OnChange = func(name string, v any) {
+		changes = append(changes, fmt.Sprintf("%s=%v", name, v))
+	}
+	return settings, &changes
+}
+
+func TestOnChangeFiresOnEveryKeystroke(t *testing.T) {
+	obj := struct {
+		Name string
+		Port int
+	}{}
+	settings, changes := recordChanges()
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SendKeys(m, "enter", "a", "b", "backspace", "enter", "down", "enter", "4", "2", "enter")
+	want := []string{"Name=a", "Name=ab", "Name=a", "Name=a", "Port=4", "Port=42", "Port=42"}
+	if !slices.Equal(*changes, want) {
+		t.Errorf("OnChange saw %q, want %q", *changes, want)
+	}
+}
+
+func TestOnChangeReportsAbandonedEdit(t *testing.T) {
+	obj := struct{ Name string }{Name: "x"}
+	settings, changes := recordChanges()
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SendKeys(m, "enter", "y", "esc")
+	want := []string{"Name=xy", "Name=x"}
+	if !slices.Equal(*changes, want) {
+		t.Errorf("OnChange saw %q, want %q", *changes, want)
+	}
+}
+
+func TestOnChangeIgnoresNavigation(t *testing.T) {
+	obj := struct{ A, B string }{}
+	settings, changes := recordChanges()
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SendKeys(m, "down", "up", "enter", "enter", "tab")
+	if len(*changes) != 0 {
+		t.Errorf("OnChange saw %q, want nothing", *changes)
+	}
+}
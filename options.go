@@ -0,0 +1,40 @@
+package gostructui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// MenuOption sets up the state of a menu as it is created by
+// InitialTModelStructMenu. Options make it possible to render
+// the menu in a known state, as when comparing View against a
+// golden file in tests.
+type MenuOption func(m *TModelStructMenu)
+
+// WithCursor starts the menu with the cursor on the field at index i,
+// counting from the top of the menu. Indexes out of range are clamped
+// to the first or last field.
+func WithCursor(i int) MenuOption {
+	return func(m *TModelStructMenu) {
+		m.cursor = max(min(i, len(m.menuFields)-1), 0)
+	}
+}
+
+// WithEditing starts the menu editing the field under the cursor,
+// as if the user had just pressed enter on it. Read-only fields
+// are left alone.
+func WithEditing() MenuOption {
+	return func(m *TModelStructMenu) {
+		if f := m.getFieldUnderCursor(); !f.isReadOnly() {
+			f.beginEdit()
+			m.isEditingValue = true
+		}
+	}
+}
+
+// WithSize starts the menu as though the terminal
+// had reported the given width and height.
+func WithSize(width, height int) MenuOption {
+	return func(m *TModelStructMenu) {
+		msg := tea.WindowSizeMsg{Width: width, Height: height}
+		m.width, m.height = width, height
+		m.syncViewport(msg)
+	}
+}
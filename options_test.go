@@ -0,0 +1,49 @@
+package gostructui
+
+import (
+	"strings"
+	"testing"
+)
+
+type optionsForm struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+func TestWithCursor(t *testing.T) {
+	var obj optionsForm
+	for _, tt := range []struct{ i, want int }{{0, 0}, {2, 2}, {-1, 0}, {9, 2}} {
+		if m := newTestMenu(t, &obj, WithCursor(tt.i)); m.cursor != tt.want {
+			t.Errorf("WithCursor(%d) put the cursor on %d, want %d", tt.i, m.cursor, tt.want)
+		}
+	}
+}
+
+func TestWithEditing(t *testing.T) {
+	obj := optionsForm{Email: "jane@example.com"}
+	m := newTestMenu(t, &obj, WithCursor(1), WithEditing())
+	if !m.isEditingValue {
+		t.Fatal("WithEditing didn't start an edit")
+	}
+	view := m.View()
+	if !strings.Contains(view, m.Settings.EditCursorChar) || !strings.Contains(view, "jane@example.com"+m.Settings.IBeamChar) {
+		t.Errorf("View doesn't show the field being edited:\n%s", view)
+	}
+
+	obj2 := struct {
+		ID string `smreadonly:"true"`
+	}{}
+	if m := newTestMenu(t, &obj2, WithEditing()); m.isEditingValue {
+		t.Error("WithEditing started an edit of a read-only field")
+	}
+}
+
+func TestOptionsRenderKnownState(t *testing.T) {
+	var obj optionsForm
+	opts := []MenuOption{WithCursor(2), WithEditing(), WithSize(80, 24)}
+	want := newTestMenu(t, &obj, opts...).View()
+	if got := SendKeys(newTestMenu(t, &obj, WithSize(80, 24)), "down", "down", "enter").View(); got != want {
+		t.Errorf("options rendered\n%s\nbut the same state reached by keys renders\n%s", want, got)
+	}
+}
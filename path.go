@@ -0,0 +1,63 @@
+package gostructui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// pathKinds lists the values of the smpath tag.
+var pathKinds = []string{"file", "dir", "writable"}
+
+// checkPath checks the value of a field tagged smpath against the
+// filesystem: "file" wants an existing regular file, "dir" an
+// existing directory, and "writable" a file that can be written,
+// either one that exists or one that could be created in an
+// existing directory. Empty values are let through, so that the
+// field may be left blank unless it is also required.
+func (f *menuField) checkPath() error {
+	if f.path == "" || f.s == "" {
+		return nil
+	}
+	switch f.path {
+	case "file":
+		info, err := os.Stat(f.s)
+		if err != nil {
+			return errors.New("no such file")
+		}
+		if !info.Mode().IsRegular() {
+			return errors.New("not a regular file")
+		}
+	case "dir":
+		info, err := os.Stat(f.s)
+		if err != nil {
+			return errors.New("no such directory")
+		}
+		if !info.IsDir() {
+			return errors.New("not a directory")
+		}
+	case "writable":
+		info, err := os.Stat(f.s)
+		if errors.Is(err, os.ErrNotExist) {
+			dir, err := os.Stat(filepath.Dir(f.s))
+			if err != nil || !dir.IsDir() {
+				return fmt.Errorf("no such directory %s", filepath.Dir(f.s))
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return errors.New("is a directory")
+		}
+		// opening for writing alone leaves the file as it is
+		file, err := os.OpenFile(f.s, os.O_WRONLY, 0)
+		if err != nil {
+			return errors.New("not writable")
+		}
+		file.Close()
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package gostructui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckPath(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		kind, path string
+		ok         bool
+	}{
+		{"file", file, true},
+		{"file", dir, false},
+		{"file", filepath.Join(dir, "missing"), false},
+		{"dir", dir, true},
+		{"dir", file, false},
+		{"writable", file, true},
+		{"writable", filepath.Join(dir, "new.toml"), true},
+		{"writable", filepath.Join(dir, "missing", "new.toml"), false},
+		{"writable", dir, false},
+		{"file", "", true},
+	} {
+		f := menuField{kind: FieldString, path: tt.kind, s: tt.path}
+		if err := f.checkPath(); (err == nil) != tt.ok {
+			t.Errorf("checkPath() for %s %q = %v, want ok %v", tt.kind, tt.path, err, tt.ok)
+		}
+	}
+}
+
+func TestSaveChecksPath(t *testing.T) {
+	obj := struct {
+		ConfigPath string `smpath:"file"`
+	}{ConfigPath: filepath.Join(t.TempDir(), "missing")}
+	m := SendKeys(newTestMenu(t, &obj), "s")
+	if m.saved || !strings.Contains(m.View(), "no such file") {
+		t.Errorf("saving with a missing file went through:\n%s", m.View())
+	}
+}
+
+func TestViewDoesNotRecheckValidity(t *testing.T) {
+	obj := struct{ Name string }{}
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.BlockSaveWhenInvalid = true
+	m, err := InitialTModelStructMenu(&obj, nil, false, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	m.AddValidator("Name", func(v any) error {
+		calls++
+		if v == "" {
+			return errors.New("must not be empty")
+		}
+		return nil
+	})
+	calls = 0
+	m.View()
+	m.View()
+	if calls != 0 {
+		t.Errorf("View ran validators %d times", calls)
+	}
+	if !strings.Contains(m.View(), "Saving is blocked") {
+		t.Errorf("View doesn't show saving as blocked:\n%s", m.View())
+	}
+
+	m = SendKeys(m, "enter", "x", "enter")
+	if calls == 0 {
+		t.Error("changing a value didn't check validity")
+	}
+	if strings.Contains(m.View(), "Saving is blocked") {
+		t.Errorf("View still shows saving as blocked:\n%s", m.View())
+	}
+}
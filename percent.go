@@ -0,0 +1,33 @@
+package gostructui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// percentBarCells is the number of cells in the bar of a percentage field.
+const percentBarCells = 10
+
+// percentBounds gives a percentage field the bounds of 0 and 100,
+// where the smmin and smmax tags didn't set bounds of their own.
+func (f *menuField) percentBounds() {
+	if f.minVal == nil {
+		f.minVal = new(int64)
+	}
+	if f.maxVal == nil {
+		hundred := int64(100)
+		f.maxVal = &hundred
+	}
+}
+
+// renderPercent renders the value of a percentage field
+// as a bar alongside the number, as in "[■■■■■□□□□□] 50%".
+func (f *menuField) renderPercent() string {
+	v := f.i
+	if f.kind == FieldUint {
+		v = uintAsInt64(f.u)
+	}
+	filled := int(min(max((v*percentBarCells+50)/100, 0), percentBarCells))
+	bar := strings.Repeat("■", filled) + strings.Repeat("□", percentBarCells-filled)
+	return fmt.Sprintf("[%s] %d%%", bar, v)
+}
@@ -0,0 +1,76 @@
+package gostructui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type pointerForm struct {
+	Count *int
+	Name  *string
+}
+
+func TestUntouchedNilPointerStaysNil(t *testing.T) {
+	var obj pointerForm
+	m := newTestMenu(t, &obj)
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Count != nil || obj.Name != nil {
+		t.Errorf("ParseStruct set untouched nil pointers: %+v", obj)
+	}
+}
+
+func TestEnteringZeroSetsNilPointer(t *testing.T) {
+	var obj pointerForm
+	m := SendKeys(newTestMenu(t, &obj), "enter", "0", "enter")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Count == nil || *obj.Count != 0 {
+		t.Errorf("Count = %v, want pointer to 0", obj.Count)
+	}
+	if obj.Name != nil {
+		t.Errorf("Name = %q, want nil", *obj.Name)
+	}
+}
+
+func TestCancelledEditLeavesNilPointerNil(t *testing.T) {
+	var obj pointerForm
+	m := SendKeys(newTestMenu(t, &obj), "enter", "0", "esc")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Count != nil {
+		t.Errorf("Count = %d, want nil", *obj.Count)
+	}
+}
+
+func TestNonNilPointerIsWrittenThrough(t *testing.T) {
+	n := 3
+	obj := pointerForm{Count: &n}
+	m := SendKeys(newTestMenu(t, &obj), "right")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Count != &n || n != 4 {
+		t.Errorf("Count = %d, want the same pointer to 4", *obj.Count)
+	}
+}
+
+func TestPointerToUnsupportedTypeIsSkipped(t *testing.T) {
+	obj := struct {
+		Name string
+		Ch   *chan int
+	}{}
+	m := newTestMenu(t, &obj)
+	if len(m.menuFields) != 1 || len(m.Warnings()) != 1 {
+		t.Errorf("got %d fields and warnings %q, want 1 field and 1 warning", len(m.menuFields), m.Warnings())
+	}
+	// warnings can be read off the model handed back by Update
+	var model tea.Model = m
+	if warnings := model.(TModelStructMenu).Warnings(); len(warnings) != 1 {
+		t.Errorf("Warnings() through tea.Model = %q, want 1 warning", warnings)
+	}
+}
@@ -0,0 +1,34 @@
+package gostructui
+
+// ResetAll restores every field to the value it held
+// when the menu was created, and starts the user over
+// from the top of the menu.
+func (m *TModelStructMenu) ResetAll() {
+	m.resetWith((*menuField).reset)
+}
+
+// ResetToDefaults sets every field to the value given by its
+// smdefault tag, or to its zero value if it has none, as if
+// the menu had been created from an empty struct. It starts
+// the user over from the top of the menu.
+func (m *TModelStructMenu) ResetToDefaults() {
+	m.resetWith((*menuField).resetToDefault)
+}
+
+// resetWith applies reset to every field the user may edit,
+// then clears any edit, confirmation or validation in progress.
+func (m *TModelStructMenu) resetWith(reset func(f *menuField)) {
+	for i := range m.menuFields {
+		if f := m.getFieldAtIndex(i); !f.isReadOnly() {
+			reset(f)
+		}
+	}
+	m.cursor = 0
+	m.isEditingValue = false
+	m.confirmingSave = false
+	m.confirmingCancel = false
+	m.validationErrs = nil
+	m.submitErr = ""
+	m.recompute()
+	m.checkValidity()
+}
@@ -0,0 +1,25 @@
+package gostructui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// RunStructMenu covers the common case of exposing a struct to users
+// in one call. It builds a menu from obj (see InitialTModelStructMenu),
+// runs it as a bubbletea program with the given options, and writes the
+// values entered by the user back into obj. The returned bool reports
+// whether the user cancelled, in which case obj is left untouched.
+func RunStructMenu[T any](obj *T, fieldList []string, asBlacklist bool, customSettings *MenuSettings, opts ...tea.ProgramOption) (*T, bool, error) {
+	menu, err := NewMenu(obj, fieldList, asBlacklist, customSettings)
+	if err != nil {
+		return obj, false, err
+	}
+	return menu.Run(opts...)
+}
+
+// Edit is RunStructMenu for the most common case, where every field
+// of obj but those named in blacklist is exposed. It reports whether
+// the user cancelled, and any error from running the menu or writing
+// the values back into obj.
+func Edit[T any](obj *T, blacklist []string, settings *MenuSettings) (cancelled bool, err error) {
+	_, cancelled, err = RunStructMenu(obj, blacklist, true, settings)
+	return cancelled, err
+}
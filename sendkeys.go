@@ -0,0 +1,58 @@
+package gostructui
+
+import (
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SendKeys feeds the given keys through the Update method of the menu,
+// one at a time and in order, and returns the resulting menu. Keys are
+// named as bubbletea names them, so that special keys may be given as
+// "enter", "backspace", "tab", "ctrl+c" and the like, and the space bar
+// as " " or "space", while any other string is typed out as is; an
+// "alt+" prefix holds down alt. Commands returned by Update are not
+// run, which makes SendKeys a way to drive the menu through a flow in
+// tests, without a bubbletea program.
+//
+//	m = gostructui.SendKeys(m, "enter", "h", "e", "l", "l", "o", "enter")
+func SendKeys(m TModelStructMenu, keys ...string) TModelStructMenu {
+	for _, key := range keys {
+		updated, _ := m.Update(tea.KeyMsg(parseKey(key)))
+		m = updated.(TModelStructMenu)
+	}
+	return m
+}
+
+// keyTypes maps the names bubbletea gives
+// to special keys back to their key types,
+// along with "space" for the space bar.
+var keyTypes = sync.OnceValue(func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	// bubbletea numbers special keys just above and below zero
+	for k := tea.KeyType(-128); k <= 127; k++ {
+		if name := k.String(); name != "" && k != tea.KeyRunes {
+			if _, ok := types[name]; !ok {
+				types[name] = k
+			}
+		}
+	}
+	// bubbletea names the space bar " ", which is easy to miss
+	types["space"] = tea.KeySpace
+	return types
+})
+
+// parseKey returns the key press the given key name stands for.
+func parseKey(name string) tea.Key {
+	key := tea.Key{}
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		key.Alt, name = true, rest
+	}
+	if k, ok := keyTypes()[name]; ok {
+		key.Type = k
+	} else {
+		key.Type, key.Runes = tea.KeyRunes, []rune(name)
+	}
+	return key
+}
@@ -0,0 +1,46 @@
+package gostructui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseKey(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		want tea.Key
+	}{
+		{"enter", tea.Key{Type: tea.KeyEnter}},
+		{"backspace", tea.Key{Type: tea.KeyBackspace}},
+		{"tab", tea.Key{Type: tea.KeyTab}},
+		{"shift+tab", tea.Key{Type: tea.KeyShiftTab}},
+		{"ctrl+c", tea.Key{Type: tea.KeyCtrlC}},
+		{" ", tea.Key{Type: tea.KeySpace}},
+		{"space", tea.Key{Type: tea.KeySpace}},
+		{"h", tea.Key{Type: tea.KeyRunes, Runes: []rune("h")}},
+		{"hello", tea.Key{Type: tea.KeyRunes, Runes: []rune("hello")}},
+		{"alt+x", tea.Key{Type: tea.KeyRunes, Runes: []rune("x"), Alt: true}},
+		{"alt+", tea.Key{Type: tea.KeyRunes, Runes: []rune("alt+")}},
+	} {
+		got := parseKey(tt.name)
+		if got.Type != tt.want.Type || string(got.Runes) != string(tt.want.Runes) || got.Alt != tt.want.Alt {
+			t.Errorf("parseKey(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+		// keys named as bubbletea names them read back the same
+		if tt.name != "hello" && tt.name != "alt+" && tt.name != "space" && tea.KeyMsg(got).String() != tt.name {
+			t.Errorf("parseKey(%q) reads back as %q", tt.name, tea.KeyMsg(got).String())
+		}
+	}
+}
+
+func TestSendKeysDrivesFlow(t *testing.T) {
+	obj := struct{ Name string }{}
+	m := SendKeys(newTestMenu(t, &obj), "enter", "h", "e", "l", "l", "o", "enter")
+	if v, _ := m.FieldValue("Name"); v != "hello" {
+		t.Errorf("Name = %q, want %q", v, "hello")
+	}
+	if m.isEditingValue {
+		t.Error("the edit wasn't committed")
+	}
+}
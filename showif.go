@@ -0,0 +1,101 @@
+package gostructui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// condition is the condition of an smshowif tag on another field,
+// named by the name of its struct field.
+type condition struct {
+	field  string // name of the field the condition is on
+	value  string // text its value is compared against, if op is set
+	op     string // "==" or "!=", or empty to test for a non-zero value
+	negate bool   // whether the condition is met by a zero value instead
+}
+
+// parseCondition reads the value of an smshowif tag, which is one
+// of "Field", "!Field", "Field==value" and "Field!=value".
+func parseCondition(tag string) (*condition, error) {
+	c := &condition{}
+	for _, op := range []string{"==", "!="} {
+		if name, value, ok := strings.Cut(tag, op); ok {
+			c.field, c.op, c.value = strings.TrimSpace(name), op, strings.TrimSpace(value)
+			break
+		}
+	}
+	if c.op == "" {
+		name, negate := strings.CutPrefix(strings.TrimSpace(tag), "!")
+		c.field, c.negate = name, negate
+	}
+	if c.field == "" {
+		return nil, errors.New("no field named")
+	}
+	return c, nil
+}
+
+// met reports whether the condition holds for the field it is on.
+// Values are compared as text, as in "CanTravel==true".
+func (c *condition) met(f *menuField) bool {
+	switch c.op {
+	case "==":
+		return f.text() == c.value
+	case "!=":
+		return f.text() != c.value
+	}
+	return isEmptyValue(f.value()) == c.negate
+}
+
+// checkConditions makes sure the smshowif tag of every field names
+// another field of the menu, and that no field depends on itself,
+// however indirectly, which would leave it no way to be shown.
+func (m *TModelStructMenu) checkConditions() error {
+	for i := range m.menuFields {
+		f := m.getFieldAtIndex(i)
+		seen := map[string]bool{f.name: true}
+		for c := f.showIf; c != nil; {
+			on := m.getFieldByName(c.field)
+			if on == nil {
+				return fmt.Errorf("invalid smshowif tag on field '%s': no field '%s' in menu", f.name, c.field)
+			}
+			if seen[on.name] {
+				return fmt.Errorf("invalid smshowif tag on field '%s': fields depend on each other in a circle", f.name)
+			}
+			seen[on.name] = true
+			c = on.showIf
+		}
+	}
+	return nil
+}
+
+// isShown reports whether the smshowif condition of the field, if
+// any, is met. A field whose condition is on a field that isn't
+// shown itself isn't shown either.
+func (m *TModelStructMenu) isShown(f *menuField) bool {
+	for c := f.showIf; c != nil; {
+		on := m.getFieldByName(c.field)
+		if on == nil || !c.met(on) {
+			return false
+		}
+		c = on.showIf
+	}
+	return true
+}
+
+// keepCursorShown moves the cursor off a field that is no longer
+// shown, as when the field its condition is on has just changed,
+// to the nearest field below it that is, or else above it.
+func (m *TModelStructMenu) keepCursorShown() {
+	if !m.cursorInRange() || m.isShown(m.getFieldUnderCursor()) {
+		return
+	}
+	for _, step := range []int{1, -1} {
+		for i := m.cursor + step; i >= 0 && i < len(m.menuFields); i += step {
+			if !m.isHidden(i) {
+				m.cursor = i
+				return
+			}
+		}
+	}
+}
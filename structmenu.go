@@ -0,0 +1,56 @@
+package gostructui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// StructMenu is a menu exposing a struct of type T, built by
+// NewMenu. It runs as a bubbletea model like TModelStructMenu,
+// which it embeds, but keeps its state behind a pointer, so the
+// menu handed to bubbletea can be read again once the program
+// returns, without asserting the type of the final model.
+type StructMenu[T any] struct {
+	TModelStructMenu
+	obj *T
+}
+
+// NewMenu builds a menu exposing the fields of obj, taking the same
+// arguments as InitialTModelStructMenu. Unlike the menu that returns,
+// its result is read back as a *T through Result.
+func NewMenu[T any](obj *T, fieldList []string, asBlacklist bool, customSettings *MenuSettings, opts ...MenuOption) (*StructMenu[T], error) {
+	menu, err := InitialTModelStructMenu(obj, fieldList, asBlacklist, customSettings, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &StructMenu[T]{TModelStructMenu: menu, obj: obj}, nil
+}
+
+// Update handles the message as TModelStructMenu does, keeping
+// the new state in m.
+func (m *StructMenu[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.TModelStructMenu.Update(msg)
+	m.TModelStructMenu = model.(TModelStructMenu)
+	return m, cmd
+}
+
+// Result writes the values entered by the user into the struct
+// the menu was built from, and returns it.
+func (m *StructMenu[T]) Result() (*T, error) {
+	if err := m.ParseStruct(m.obj); err != nil {
+		return m.obj, err
+	}
+	return m.obj, nil
+}
+
+// Run runs the menu as a bubbletea program with the given options
+// and returns the struct it was built from, filled with the values
+// entered by the user. The returned bool reports whether the user
+// cancelled, in which case the struct is left untouched.
+func (m *StructMenu[T]) Run(opts ...tea.ProgramOption) (*T, bool, error) {
+	if _, err := tea.NewProgram(m, opts...).Run(); err != nil {
+		return m.obj, false, err
+	}
+	if m.QuitWithCancel {
+		return m.obj, true, nil
+	}
+	obj, err := m.Result()
+	return obj, false, err
+}
@@ -0,0 +1,36 @@
+package gostructui
+
+import "github.com/charmbracelet/lipgloss"
+
+// MenuStyles holds optional lipgloss styles for the parts of the menu.
+// A nil style leaves its part of the menu rendered as plain text.
+type MenuStyles struct {
+	Header      *lipgloss.Style // the header above the fields
+	FocusedRow  *lipgloss.Style // the whole row of the field under the cursor
+	Cursor      *lipgloss.Style // the cursor pointing at a field
+	Label       *lipgloss.Style // the names of fields
+	Value       *lipgloss.Style // the values of fields
+	Description *lipgloss.Style // the description of the field under the cursor
+	Footer      *lipgloss.Style // the key hints below the fields
+	Error       *lipgloss.Style // errors, and the names of fields failing validation; red if nil
+	Placeholder *lipgloss.Style // placeholders of empty fields; dimmed if nil
+	Suggestion  *lipgloss.Style // completions suggested while typing; dimmed and underlined if nil
+	ReadOnly    *lipgloss.Style // values of read-only fields; dimmed if nil
+	Group       *lipgloss.Style // headings of groups of fields; bold if nil
+}
+
+// styleOr returns the style, or def if there is none.
+func styleOr(style *lipgloss.Style, def lipgloss.Style) lipgloss.Style {
+	if style == nil {
+		return def
+	}
+	return *style
+}
+
+// render applies the style to s, if there is a style to apply.
+func render(style *lipgloss.Style, s string) string {
+	if style == nil {
+		return s
+	}
+	return style.Render(s)
+}
@@ -0,0 +1,53 @@
+package gostructui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// suggestionStyle sets suggested completions apart from
+// the text typed so far, and from placeholders.
+var suggestionStyle = lipgloss.NewStyle().Faint(true).Underline(true)
+
+// suggestion returns the first suggestion of a string field being
+// edited that completes the text typed so far, regardless of case.
+// The returned bool is false if there is none, or if the caret is
+// not at the end of the text, where completions are added.
+func (f *menuField) suggestion() (string, bool) {
+	runes := []rune(f.editBuf)
+	if f.editBuf == "" || f.caret != len(runes) {
+		return "", false
+	}
+	for _, suggestion := range f.suggest {
+		if len([]rune(suggestion)) > len(runes) && strings.EqualFold(string([]rune(suggestion)[:len(runes)]), f.editBuf) {
+			return suggestion, true
+		}
+	}
+	return "", false
+}
+
+// acceptSuggestion completes the text typed into a string field with
+// its suggestion, if it has one, placing the caret at the end. It
+// reports whether there was a suggestion to accept.
+func (f *menuField) acceptSuggestion() bool {
+	suggestion, ok := f.suggestion()
+	if !ok {
+		return false
+	}
+	f.editBuf = suggestion
+	f.caret = len([]rune(suggestion))
+	return true
+}
+
+// renderSuggestion renders the rest of the suggestion completing
+// the text typed into a string field, in the given style, or
+// dimmed and underlined if there is none.
+func (f *menuField) renderSuggestion(style *lipgloss.Style) string {
+	suggestion, ok := f.suggestion()
+	if !ok {
+		return ""
+	}
+	rest := string([]rune(suggestion)[len([]rune(f.editBuf)):])
+	return styleOr(style, suggestionStyle).Render(f.maskString(rest))
+}
@@ -0,0 +1,59 @@
+package gostructui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// textAreaRows is the number of lines of a multiline field shown
+// at once. Longer values scroll to keep the caret in view while
+// being edited, and are cut short otherwise.
+const textAreaRows = 5
+
+// renderTextArea renders the value of a multiline string field
+// across rows, with the caret shown as iBeamChar if editing.
+func (f *menuField) renderTextArea(editing bool, iBeamChar string, placeholder *lipgloss.Style) string {
+	if !editing {
+		if f.s == "" {
+			return f.renderPlaceholder(placeholder)
+		}
+		lines := strings.Split(f.maskString(f.s), "\n")
+		if len(lines) > textAreaRows {
+			lines = append(lines[:textAreaRows], "…")
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	runes := []rune(f.maskString(f.editBuf))
+	lines := strings.Split(string(runes[:f.caret])+iBeamChar+string(runes[f.caret:]), "\n")
+	// scroll just far enough for the line of the caret to show
+	line, _ := f.caretPos()
+	start := max(line-textAreaRows+1, 0)
+	return strings.Join(lines[start:min(start+textAreaRows, len(lines))], "\n")
+}
+
+// caretPos returns the line and column of the caret
+// in the value being typed, counted in runes.
+func (f *menuField) caretPos() (line, col int) {
+	before := string([]rune(f.editBuf)[:f.caret])
+	line = strings.Count(before, "\n")
+	col = len([]rune(before[strings.LastIndex(before, "\n")+1:]))
+	return line, col
+}
+
+// moveCaretLine moves the caret delta lines up or down in the value
+// being typed, keeping to its column as far as the line allows.
+func (f *menuField) moveCaretLine(delta int) {
+	line, col := f.caretPos()
+	lines := strings.Split(f.editBuf, "\n")
+	line += delta
+	if line < 0 || line >= len(lines) {
+		return
+	}
+	f.caret = 0
+	for _, l := range lines[:line] {
+		f.caret += len([]rune(l)) + 1
+	}
+	f.caret += min(col, len([]rune(lines[line])))
+}
@@ -0,0 +1,84 @@
+package gostructui
+
+import "github.com/charmbracelet/lipgloss"
+
+// ThemeDefault returns initialized menu settings styled with
+// muted colors that read well on light and dark terminals alike.
+func ThemeDefault() *MenuSettings {
+	return newThemedSettings(themeColors{
+		accent:      lipgloss.AdaptiveColor{Light: "#5A56E0", Dark: "#7571F9"},
+		text:        lipgloss.AdaptiveColor{Light: "#1A1A1A", Dark: "#DDDDDD"},
+		subtle:      lipgloss.AdaptiveColor{Light: "#9B9B9B", Dark: "#5C5C5C"},
+		description: lipgloss.AdaptiveColor{Light: "#555555", Dark: "#A0A0A0"},
+		err:         lipgloss.AdaptiveColor{Light: "#D70000", Dark: "#FF5F5F"},
+	})
+}
+
+// ThemeDark returns initialized menu settings styled
+// for terminals with a dark background.
+func ThemeDark() *MenuSettings {
+	return newThemedSettings(themeColors{
+		accent:      lipgloss.Color("#00D7AF"),
+		text:        lipgloss.Color("#E4E4E4"),
+		subtle:      lipgloss.Color("#6C6C6C"),
+		description: lipgloss.Color("#AFAFAF"),
+		err:         lipgloss.Color("#FF5F87"),
+	})
+}
+
+// ThemeHighContrast returns initialized menu settings styled for
+// legibility: bold, bright colors on the terminal's own background,
+// a reversed row under the cursor, and no dimmed text.
+func ThemeHighContrast() *MenuSettings {
+	settings := newThemedSettings(themeColors{
+		accent:      lipgloss.Color("11"), // bright yellow
+		text:        lipgloss.Color("15"), // bright white
+		subtle:      lipgloss.Color("14"), // bright cyan
+		description: lipgloss.Color("15"),
+		err:         lipgloss.Color("9"), // bright red
+	})
+	focus := lipgloss.NewStyle().Bold(true).Reverse(true)
+	placeholder := lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Italic(true)
+	settings.Styles.FocusedRow = &focus
+	settings.Styles.Placeholder = &placeholder
+	settings.Styles.ReadOnly = &placeholder
+	return settings
+}
+
+// themeColors holds the colors a theme is built from.
+type themeColors struct {
+	accent      lipgloss.TerminalColor // the header, cursor and focused row
+	text        lipgloss.TerminalColor // labels and values
+	subtle      lipgloss.TerminalColor // placeholders, read-only values and key hints
+	description lipgloss.TerminalColor // descriptions of fields
+	err         lipgloss.TerminalColor // errors
+}
+
+// newThemedSettings returns initialized menu
+// settings with styles built from the colors.
+func newThemedSettings(c themeColors) *MenuSettings {
+	header := lipgloss.NewStyle().Bold(true).Foreground(c.accent)
+	focus := lipgloss.NewStyle().Foreground(c.accent)
+	cursor := lipgloss.NewStyle().Bold(true).Foreground(c.accent)
+	text := lipgloss.NewStyle().Foreground(c.text)
+	subtle := lipgloss.NewStyle().Foreground(c.subtle)
+	description := lipgloss.NewStyle().Italic(true).Foreground(c.description)
+	err := lipgloss.NewStyle().Bold(true).Foreground(c.err)
+
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.Styles = MenuStyles{
+		Header:      &header,
+		Group:       &header,
+		FocusedRow:  &focus,
+		Cursor:      &cursor,
+		Label:       &text,
+		Value:       &text,
+		Description: &description,
+		Footer:      &subtle,
+		Error:       &err,
+		Placeholder: &subtle,
+		ReadOnly:    &subtle,
+	}
+	return settings
+}
@@ -0,0 +1,100 @@
+package gostructui
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// timeDigits matches the runs of digits making
+// up the components of a formatted time value.
+var timeDigits = regexp.MustCompile(`\d+`)
+
+// timeUnit adds d of some component (years, days, ...) to t.
+type timeUnit func(t time.Time, d int) time.Time
+
+// timeLayoutUnits maps the numeric elements of a time layout
+// to the component of a time value they represent.
+var timeLayoutUnits = []struct {
+	elem string
+	unit timeUnit
+}{
+	{"2006", func(t time.Time, d int) time.Time { return t.AddDate(d, 0, 0) }},
+	{"01", func(t time.Time, d int) time.Time { return t.AddDate(0, d, 0) }},
+	{"02", func(t time.Time, d int) time.Time { return t.AddDate(0, 0, d) }},
+	{"15", func(t time.Time, d int) time.Time { return t.Add(time.Duration(d) * time.Hour) }},
+	{"04", func(t time.Time, d int) time.Time { return t.Add(time.Duration(d) * time.Minute) }},
+	{"05", func(t time.Time, d int) time.Time { return t.Add(time.Duration(d) * time.Second) }},
+}
+
+// timeUnits returns the components of the field's layout,
+// in the order they appear in formatted values.
+func (f *menuField) timeUnits() []timeUnit {
+	var units []timeUnit
+	for layout := f.layout; layout != ""; {
+		matched := false
+		for _, lu := range timeLayoutUnits {
+			if strings.HasPrefix(layout, lu.elem) {
+				units = append(units, lu.unit)
+				layout = layout[len(lu.elem):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			layout = layout[1:]
+		}
+	}
+	return units
+}
+
+// parseTime parses s against the field's layout.
+func (f *menuField) parseTime(s string) (time.Time, error) {
+	t, err := time.ParseInLocation(f.layout, s, f.t.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected layout %s)", s, f.layout)
+	}
+	return t, nil
+}
+
+// handleTimeKey edits a time value. Left and right move between
+// the components of the value, and up and down step the focused
+// component. Any other single character is typed into the buffer.
+func (f *menuField) handleTimeKey(key string) {
+	units := f.timeUnits()
+	switch key {
+	case "left":
+		f.part = max(f.part-1, 0)
+	case "right":
+		f.part = min(f.part+1, max(len(units)-1, 0))
+	case "up", "down":
+		t, err := f.parseTime(f.editBuf)
+		if err != nil || f.part >= len(units) {
+			return
+		}
+		d := 1
+		if key == "down" {
+			d = -1
+		}
+		f.editBuf = units[f.part](t, d).Format(f.layout)
+	default:
+		if len([]rune(key)) == 1 {
+			f.editBuf += key
+		}
+	}
+}
+
+// renderTimeEdit renders the edit buffer of a time value
+// with its focused component wrapped in brackets.
+func (f *menuField) renderTimeEdit() string {
+	runs := timeDigits.FindAllStringIndex(f.editBuf, -1)
+	if f.part >= len(runs) {
+		return f.editBuf
+	}
+	start, end := runs[f.part][0], runs[f.part][1]
+	return f.editBuf[:start] + "[" + f.editBuf[start:end] + "]" + f.editBuf[end:]
+}
@@ -0,0 +1,41 @@
+package gostructui
+
+import (
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultToastDuration is the ToastDuration used when none is set.
+const defaultToastDuration = 2 * time.Second
+
+// toastDuration returns the configured ToastDuration,
+// or the default if it was left unset.
+func (m *MenuSettings) toastDuration() time.Duration {
+	if m.ToastDuration <= 0 {
+		return defaultToastDuration
+	}
+	return m.ToastDuration
+}
+
+// toastCount numbers toasts across all menus, so that the expiry
+// of one is never taken for that of another, as on another page
+// of a wizard.
+var toastCount atomic.Int64
+
+// toastExpiredMsg tells the menu that the toast of the given
+// number has been shown for long enough.
+type toastExpiredMsg struct{ id int64 }
+
+// Toast shows a short message below the menu, as in "saved!", until
+// Settings.ToastDuration has passed or users press a key. A newer
+// toast replaces any still shown. The returned command times the
+// toast, and must be passed to bubbletea for it to go away on time.
+func (m *TModelStructMenu) Toast(text string) tea.Cmd {
+	id := toastCount.Add(1)
+	m.toast, m.toastID = text, id
+	return tea.Tick(m.Settings.toastDuration(), func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
@@ -0,0 +1,81 @@
+package gostructui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// paste feeds text through Update as a terminal delivers a paste,
+// in a single run of runes.
+func paste(m TModelStructMenu, text string) TModelStructMenu {
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text), Paste: true})
+	return updated.(TModelStructMenu)
+}
+
+// nameAfter returns the value of the Name field of a menu over a
+// struct holding only that field, after the given keys are sent.
+func nameAfter(t *testing.T, m TModelStructMenu, keys ...string) string {
+	t.Helper()
+	m = SendKeys(m, keys...)
+	var out struct{ Name string }
+	if err := m.ParseStruct(&out); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	return out.Name
+}
+
+func TestTypingMultiRuneCharacters(t *testing.T) {
+	for _, tt := range []struct {
+		keys []string
+		want string
+	}{
+		{[]string{"enter", "c", "a", "f", "é", "enter"}, "café"},
+		{[]string{"enter", "c", "a", "f", "é", "backspace", "e", "enter"}, "cafe"},
+		{[]string{"enter", "h", "i", "👍", "enter"}, "hi👍"},
+		{[]string{"enter", "h", "i", "👍", "backspace", "enter"}, "hi"},
+		{[]string{"enter", "é", "é", "left", "backspace", "enter"}, "é"},
+	} {
+		var obj struct{ Name string }
+		if got := nameAfter(t, newTestMenu(t, &obj), tt.keys...); got != tt.want {
+			t.Errorf("keys %q gave %q, want %q", tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestMaxLenCountsRunes(t *testing.T) {
+	var obj struct {
+		Name string `smmaxlen:"4"`
+	}
+	m := newTestMenu(t, &obj)
+	m = SendKeys(m, "enter", "c", "a", "f", "é", "👍", "enter")
+	var out struct{ Name string }
+	if err := m.ParseStruct(&out); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if out.Name != "café" {
+		t.Errorf("Name = %q, want %q", out.Name, "café")
+	}
+}
+
+func TestPastedTextIsNeverAKey(t *testing.T) {
+	for _, text := range []string{"left", "delete", "up", "café 👍"} {
+		var obj struct{ Name string }
+		m := paste(SendKeys(newTestMenu(t, &obj), "enter"), text)
+		if got := nameAfter(t, m, "enter"); got != text {
+			t.Errorf("pasting %q gave %q", text, got)
+		}
+	}
+}
+
+func TestPastedDigitsFillNumber(t *testing.T) {
+	var obj struct{ Port int }
+	m := paste(SendKeys(newTestMenu(t, &obj), "enter"), "8080")
+	m = SendKeys(m, "enter")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Port != 8080 {
+		t.Errorf("Port = %d, want 8080", obj.Port)
+	}
+}
@@ -0,0 +1,181 @@
+package gostructui
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// AddValidator registers a function that checks the value of the
+// named field when users save, for rules struct tags can't express.
+// The function is called with the value as the Go type of the field,
+// and any error it returns blocks the save and is shown beneath the
+// row of the field. Several validators may be added to the same field; they
+// run in the order they were added, stopping at the first error.
+func (m *TModelStructMenu) AddValidator(fieldName string, fn func(v any) error) error {
+	f := m.getFieldByName(fieldName)
+	if f == nil {
+		return fmt.Errorf("no field '%s' exposed by menu", fieldName)
+	}
+	f.validators = append(f.validators, fn)
+	m.checkValidity()
+	return nil
+}
+
+// ValidateNonEmpty is a validator for use with AddValidator
+// that refuses zero values and strings of only whitespace.
+func ValidateNonEmpty(v any) error {
+	if s, ok := v.(string); ok && strings.TrimSpace(s) == "" {
+		return errors.New("must not be empty")
+	}
+	if isEmptyValue(v) {
+		return errors.New("must not be empty")
+	}
+	return nil
+}
+
+// isEmptyValue reports whether v is a zero value, or an empty list or map.
+func isEmptyValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.IsZero() || (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0
+}
+
+// ValidateEmail is a validator for use with AddValidator that
+// refuses strings other than a bare email address, such as
+// "jane@example.com". Empty strings are let through, so that
+// the field may be left blank unless it is also required.
+func ValidateEmail(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("cannot validate %T as an email address", v)
+	}
+	if s == "" {
+		return nil
+	}
+	if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+		return errors.New("must be a valid email address")
+	}
+	return nil
+}
+
+// validate checks every field against its constraints,
+// recording any problems found by field name, then hands
+// the values to Settings.OnSubmit if they all pass. It
+// reports whether the menu is fit to be saved.
+func (m *TModelStructMenu) validate() bool {
+	m.validationErrs = map[string]string{}
+	m.submitErr = ""
+	for i := range m.menuFields {
+		// fields hidden by their smshowif condition aren't held against users
+		if f := m.getFieldAtIndex(i); m.isShown(f) {
+			m.validateField(f)
+		}
+	}
+	if len(m.validationErrs) > 0 {
+		return false
+	}
+
+	if m.Settings.OnSubmit != nil {
+		if err := m.Settings.OnSubmit(m.Values()); err != nil {
+			m.submitErr = err.Error()
+			return false
+		}
+	}
+	return true
+}
+
+// validateField checks the field against its constraints,
+// recording the first problem found by field name, or
+// clearing any recorded earlier if it passes. It reports
+// whether the field passed.
+func (m *TModelStructMenu) validateField(f *menuField) bool {
+	if m.validationErrs == nil {
+		m.validationErrs = map[string]string{}
+	}
+	delete(m.validationErrs, f.name)
+	if problem := f.problem(); problem != "" {
+		m.validationErrs[f.name] = problem
+		return false
+	}
+	return true
+}
+
+// problem returns the first problem found checking the
+// field against its constraints, or "" if there is none.
+func (f *menuField) problem() string {
+	if f.required && isEmptyValue(f.value()) {
+		return fmt.Sprintf("%s is required", f.getFieldName())
+	}
+	if err := f.checkLimits(); err != nil {
+		return err.Error()
+	}
+	if err := f.checkPath(); err != nil {
+		return fmt.Sprintf("%s: %s", f.getFieldName(), err)
+	}
+	for _, validate := range f.validators {
+		if err := validate(f.value()); err != nil {
+			return fmt.Sprintf("%s: %s", f.getFieldName(), err)
+		}
+	}
+	return ""
+}
+
+// isValid reports whether every field passes its constraints
+// as they stand, without recording any problems.
+func (m *TModelStructMenu) isValid() bool {
+	for i := range m.menuFields {
+		if f := m.getFieldAtIndex(i); m.isShown(f) && f.problem() != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// checkValidity records whether saving is held back by
+// Settings.BlockSaveWhenInvalid, for saveBlocked to report.
+// Checking fields may touch the filesystem or run validators,
+// so it is done whenever values change rather than on View.
+func (m *TModelStructMenu) checkValidity() {
+	m.invalid = m.Settings.BlockSaveWhenInvalid && !m.isValid()
+}
+
+// saveBlocked reports whether saving is held back by
+// Settings.BlockSaveWhenInvalid, as of the last change
+// of values.
+func (m *TModelStructMenu) saveBlocked() bool {
+	return m.Settings.BlockSaveWhenInvalid && m.invalid
+}
+
+// checkLimits checks the value of a field against the limits its tags
+// set, the same ones enforced while editing, so that values which never
+// went through an edit, like prefilled or loaded ones, can't slip past
+// them: the smmin and smmax bounds of numbers, and the smmaxlen, smregex
+// and smoptions of strings. Empty strings are left to smrequired.
+func (f *menuField) checkLimits() error {
+	switch f.kind {
+	case FieldInt:
+		return f.checkRange(f.i)
+	case FieldUint:
+		return f.checkRange(uintAsInt64(f.u))
+	case FieldString:
+		if f.maxLen > 0 && len([]rune(f.s)) > f.maxLen {
+			return fmt.Errorf("value of field '%s' exceeds maximum length of %d", f.getFieldName(), f.maxLen)
+		}
+		if f.s == "" {
+			return nil
+		}
+		if f.regex != nil && !f.regex.MatchString(f.s) {
+			return fmt.Errorf("value of field '%s' must match pattern %s", f.getFieldName(), f.regex)
+		}
+		if len(f.options) > 0 && !slices.Contains(f.options, f.s) {
+			return fmt.Errorf("value of field '%s' must be one of %s", f.getFieldName(), strings.Join(f.options, ", "))
+		}
+	}
+	return nil
+}
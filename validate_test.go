@@ -0,0 +1,75 @@
+package gostructui
+
+import (
+	"strings"
+	"testing"
+)
+
+type identForm struct {
+	Ident string `smregex:"^[a-zA-Z0-9_]+$"`
+}
+
+func TestRegexRejectsInvalidCharacter(t *testing.T) {
+	obj := identForm{Ident: "abc"}
+	m := SendKeys(newTestMenu(t, &obj), "enter", "-", "!", "_", "1", "enter")
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatalf("ParseStruct: %v", err)
+	}
+	if obj.Ident != "abc_1" {
+		t.Errorf("Ident = %q, want %q", obj.Ident, "abc_1")
+	}
+}
+
+func TestRegexChecksOnCommit(t *testing.T) {
+	// an empty value doesn't match, so keystrokes are let through
+	// until it does, and committing a mismatch is refused
+	var obj identForm
+	m := SendKeys(newTestMenu(t, &obj), "enter", "enter")
+	if !m.isEditingValue || m.getFieldUnderCursor().errBuf == "" {
+		t.Errorf("committing a value not matching the pattern went through")
+	}
+}
+
+func TestRegexShownInFooter(t *testing.T) {
+	var obj identForm
+	m := newTestMenu(t, &obj)
+	if view := m.View(); !strings.Contains(view, "^[a-zA-Z0-9_]+$") {
+		t.Errorf("View doesn't show the pattern of the focused field:\n%s", view)
+	}
+}
+
+func TestLimitsCheckedOnSave(t *testing.T) {
+	// prefilled values never go through an edit, so saving
+	// must check them against the limits of their tags
+	for _, tt := range []struct {
+		name string
+		obj  any
+	}{
+		{"range", &struct {
+			Age int `smmax:"120"`
+		}{Age: 200}},
+		{"maxlen", &struct {
+			Code string `smmaxlen:"3"`
+		}{Code: "abcd"}},
+		{"regex", &identForm{Ident: "a-b"}},
+		{"options", &struct {
+			Color string `smoptions:"red,green"`
+		}{Color: "blue"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &MenuSettings{}
+			settings.Init()
+			settings.BlockSaveWhenInvalid = true
+			m, err := InitialTModelStructMenu(tt.obj, nil, false, settings)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !m.saveBlocked() {
+				t.Error("a value outside the limits of its field didn't block saving")
+			}
+			if m = SendKeys(m, "s"); m.saved {
+				t.Error("saving a value outside the limits of its field went through")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package gostructui
+
+import (
+	"testing"
+	"time"
+)
+
+type viewForm struct {
+	Name     string            `smname:"Full Name" smdes:"As on your passport"`
+	Age      int               `smmin:"0" smmax:"150"`
+	Admin    bool              `smgroup:"Access"`
+	Tags     []string          `smgroup:"Access"`
+	Labels   map[string]string `smgroup:"Access"`
+	Started  time.Time
+	Timeout  time.Duration
+	Password string `smmask:"true"`
+}
+
+func TestViewIsDeterministic(t *testing.T) {
+	obj := viewForm{
+		Name:    "Jane",
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"z": "1", "a": "2", "m": "3"},
+		Started: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Timeout: time.Minute,
+	}
+	for name, opts := range map[string][]MenuOption{
+		"plain":   nil,
+		"sized":   {WithSize(80, 10)},
+		"editing": {WithCursor(1), WithEditing()},
+	} {
+		m := newTestMenu(t, &obj, opts...)
+		first, second := m.View(), m.View()
+		if first != second {
+			t.Errorf("%s: View changed between calls:\n%s\n---\n%s", name, first, second)
+		}
+		if other := newTestMenu(t, &obj, opts...).View(); other != first {
+			t.Errorf("%s: View differs between menus in the same state:\n%s\n---\n%s", name, first, other)
+		}
+	}
+}
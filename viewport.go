@@ -0,0 +1,88 @@
+package gostructui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// viewportActive reports whether the fields should be rendered
+// through the viewport, which they are whenever the menu is too
+// tall for the terminal, or always if Settings.UseViewport is set.
+// The viewport is only used once the size of the terminal is known.
+func (m TModelStructMenu) viewportActive() bool {
+	if m.height <= 0 {
+		return false
+	}
+	return m.Settings.UseViewport || m.tallerThanTerminal()
+}
+
+// tallerThanTerminal reports whether the menu, with all
+// of its fields laid out, has more lines than fit the height
+// of the terminal.
+func (m TModelStructMenu) tallerThanTerminal() bool {
+	lines := strings.Count(m.headerView(), "\n") + strings.Count(m.fieldsView(), "\n") + strings.Count(m.footerView(), "\n")
+	return lines > m.height
+}
+
+// syncViewport refreshes the content and size of the viewport
+// after an update, scrolling so that the cursor stays in view.
+// Scrolling caused by the mouse wheel is left alone, so that
+// users may look around without moving the cursor.
+func (m *TModelStructMenu) syncViewport(msg tea.Msg) {
+	if !m.viewportActive() {
+		return
+	}
+
+	chrome := strings.Count(m.headerView(), "\n") + strings.Count(m.footerView(), "\n") + 1
+	m.viewport.Width = m.width
+	m.viewport.Height = max(m.height-chrome, 1)
+	m.viewport.SetContent(strings.TrimSuffix(m.fieldsView(), "\n"))
+
+	// make room for the scroll indicators if the fields don't all fit
+	if m.viewport.TotalLineCount() > m.viewport.Height {
+		m.viewport.Height = max(m.height-chrome-2, 1)
+	}
+	// a taller terminal may have room for fields scrolled past
+	m.viewport.SetYOffset(m.viewport.YOffset)
+
+	if _, ok := msg.(tea.MouseMsg); ok || !m.cursorInRange() {
+		return
+	}
+	// the heading of a group is brought into view along with
+	// its first field, as are the lines of any field below its
+	// row, such as its description, as far as they fit without
+	// hiding the row
+	line := m.cursorLine()
+	top := line - strings.Count(m.groupHeader(m.cursor), "\n")
+	end := line + strings.Count(m.fieldBlock(m.cursor), "\n") - 1
+	if top < m.viewport.YOffset {
+		m.viewport.SetYOffset(top)
+	} else if bottom := m.viewport.YOffset + m.viewport.Height; end >= bottom {
+		m.viewport.SetYOffset(min(end-m.viewport.Height+1, line))
+	}
+}
+
+// cursorLine returns the line of the fields view the cursor is on,
+// counting the rows, group headings and descriptions above it.
+func (m TModelStructMenu) cursorLine() int {
+	return m.layout()[m.cursor].line
+}
+
+// scrolledFieldsView renders the fields visible through the viewport.
+// When some are clipped, the view is framed by indicators telling
+// users which way there are more fields to scroll to.
+func (m TModelStructMenu) scrolledFieldsView() string {
+	if m.viewport.AtTop() && m.viewport.AtBottom() {
+		return m.viewport.View() + "\n"
+	}
+
+	var above, below string
+	if !m.viewport.AtTop() {
+		above = "↑ more"
+	}
+	if !m.viewport.AtBottom() {
+		below = "↓ more"
+	}
+	return above + "\n" + m.viewport.View() + "\n" + below + "\n"
+}
@@ -0,0 +1,92 @@
+package gostructui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newLongForm returns a pointer to a struct of n string fields,
+// named F00, F01 and so on.
+func newLongForm(n int) any {
+	fields := make([]reflect.StructField, n)
+	for i := range fields {
+		fields[i] = reflect.StructField{Name: fmt.Sprintf("F%02d", i), Type: reflect.TypeOf("")}
+	}
+	return reflect.New(reflect.StructOf(fields)).Interface()
+}
+
+// lineCount returns the number of lines of the rendered view s.
+func lineCount(s string) int {
+	return strings.Count(s, "\n")
+}
+
+func TestTallFormScrollsWithoutUseViewport(t *testing.T) {
+	m := newTestMenu(t, newLongForm(30), WithSize(80, 20))
+	view := m.View()
+	if n := lineCount(view); n > 20 {
+		t.Errorf("View has %d lines on a terminal 20 tall:\n%s", n, view)
+	}
+	if !strings.Contains(view, "↓ more") || strings.Contains(view, "F29") {
+		t.Errorf("View doesn't clip the end of the form:\n%s", view)
+	}
+}
+
+func TestViewportKeepsCursorInView(t *testing.T) {
+	m := newTestMenu(t, newLongForm(30), WithSize(80, 20))
+	for i := 1; i < 30; i++ {
+		m = SendKeys(m, "down")
+		view := m.View()
+		if name := fmt.Sprintf("F%02d", i); !strings.Contains(view, name) {
+			t.Fatalf("cursor on F%02d is out of view:\n%s", i, view)
+		}
+		if n := lineCount(view); n > 20 {
+			t.Fatalf("View has %d lines on a terminal 20 tall:\n%s", n, view)
+		}
+	}
+	if view := m.View(); !strings.Contains(view, "↑ more") || strings.Contains(view, "↓ more") {
+		t.Errorf("View at the end of the form shows the wrong indicators:\n%s", view)
+	}
+}
+
+func TestShortFormIsNotScrolled(t *testing.T) {
+	m := newTestMenu(t, newLongForm(3), WithSize(80, 40))
+	view := m.View()
+	if strings.Contains(view, "more") {
+		t.Errorf("View of a form that fits shows scroll indicators:\n%s", view)
+	}
+	if n := lineCount(view); n >= 40 {
+		t.Errorf("View of a form that fits is padded to %d lines", n)
+	}
+}
+
+func TestUseViewportScrollsToCursor(t *testing.T) {
+	settings := &MenuSettings{}
+	settings.Init()
+	settings.UseViewport = true
+	m, err := InitialTModelStructMenu(newLongForm(30), nil, false, settings, WithSize(80, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.viewport.YOffset != 0 {
+		t.Fatalf("viewport starts scrolled to %d", m.viewport.YOffset)
+	}
+	m = SendKeys(m, "end")
+	if m.viewport.YOffset == 0 || !strings.Contains(m.View(), "F29") {
+		t.Errorf("viewport didn't scroll to the cursor on the last field:\n%s", m.View())
+	}
+	m = SendKeys(m, "home")
+	if m.viewport.YOffset != 0 || !strings.Contains(m.View(), "F00") {
+		t.Errorf("viewport didn't scroll back to the first field:\n%s", m.View())
+	}
+
+	// the mouse wheel scrolls without moving the cursor
+	updated, _ := m.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	m = updated.(TModelStructMenu)
+	if m.viewport.YOffset == 0 || m.cursor != 0 {
+		t.Errorf("wheel left the viewport at %d and the cursor on %d", m.viewport.YOffset, m.cursor)
+	}
+}
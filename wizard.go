@@ -0,0 +1,107 @@
+package gostructui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TModelWizard is a bubbletea model that walks users through
+// several struct menus, one page at a time, as in a multi-step
+// form. Saving a page moves on to the next one, and the wizard
+// quits once the last page is saved.
+type TModelWizard struct {
+	pages          []TModelStructMenu
+	step           int  // which page is being shown
+	QuitWithCancel bool // set if the user cancelled on any page
+}
+
+// NewWizard creates a wizard going through the given menus in order.
+// Each menu keeps its own settings, including the keys that save
+// and cancel it, and the Back key that returns to the page before.
+func NewWizard(pages ...TModelStructMenu) (TModelWizard, error) {
+	if len(pages) == 0 {
+		return TModelWizard{}, errors.New("a wizard needs at least one page")
+	}
+	return TModelWizard{pages: slices.Clone(pages)}, nil
+}
+
+// Pages returns the menus of the wizard, holding the values entered
+// on each. Once the wizard is done, pass each menu's values back to
+// its own struct with ParseStruct.
+func (w TModelWizard) Pages() []TModelStructMenu {
+	return slices.Clone(w.pages)
+}
+
+// Step returns the index of the page being shown.
+func (w TModelWizard) Step() int {
+	return w.step
+}
+
+func (w TModelWizard) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, len(w.pages))
+	for i, page := range w.pages {
+		cmds[i] = page.Init()
+	}
+	return tea.Batch(cmds...)
+}
+
+func (w TModelWizard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	// Every page is laid out against the terminal, not just the one shown.
+	case tea.WindowSizeMsg:
+		for i := range w.pages {
+			updated, _ := w.pages[i].Update(msg)
+			w.pages[i] = updated.(TModelStructMenu)
+		}
+		return w, nil
+
+	// Clicks are placed below the step shown above the page.
+	case tea.MouseMsg:
+		msg.Y--
+		updated, cmd := w.pages[w.step].Update(msg)
+		w.pages[w.step] = updated.(TModelStructMenu)
+		return w, cmd
+
+	// Going back is up to the wizard, keeping the values on every page.
+	case tea.KeyMsg:
+		page := w.pages[w.step]
+		keys := page.Settings.Keys.withDefaults()
+		idle := !page.isEditingValue && !page.confirmingSave && !page.confirmingCancel &&
+			!page.filtering && !page.showingHelp
+		if w.step > 0 && idle && keyIn(msg, keys.Back) {
+			w.step--
+			return w, nil
+		}
+	}
+
+	updated, cmd := w.pages[w.step].Update(msg)
+	page := updated.(TModelStructMenu)
+
+	// The page quits when it is saved or cancelled,
+	// which only the last page passes on to bubbletea.
+	switch {
+	case page.QuitWithCancel:
+		w.pages[w.step] = page
+		w.QuitWithCancel = true
+		return w, tea.Quit
+	case page.saved && w.step < len(w.pages)-1:
+		page.saved = false
+		w.pages[w.step] = page
+		w.step++
+		return w, nil
+	}
+	w.pages[w.step] = page
+	return w, cmd
+}
+
+func (w TModelWizard) View() string {
+	s := fmt.Sprintf("Step %d of %d\n", w.step+1, len(w.pages))
+	s += w.pages[w.step].View()
+	if keys := w.pages[w.step].Settings.Keys.withDefaults(); w.step > 0 && len(keys.Back) > 0 {
+		s += fmt.Sprintf("Press %s to go back.\n", keys.Back[0])
+	}
+	return s
+}
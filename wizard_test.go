@@ -0,0 +1,37 @@
+package gostructui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sendWizard passes each of keys to the wizard in turn.
+func sendWizard(w TModelWizard, keys ...string) TModelWizard {
+	for _, key := range keys {
+		updated, _ := w.Update(tea.KeyMsg(parseKey(key)))
+		w = updated.(TModelWizard)
+	}
+	return w
+}
+
+func TestWizardBackKeyIgnoredWhileFilteringOrShowingHelp(t *testing.T) {
+	first := struct{ Name string }{}
+	second := struct{ Email, Phone string }{}
+	w, err := NewWizard(newTestMenu(t, &first), newTestMenu(t, &second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w = sendWizard(w, "s"); w.Step() != 1 {
+		t.Fatalf("saving the first page left the wizard on step %d", w.Step())
+	}
+	if w = sendWizard(w, "/", "b"); w.Step() != 1 {
+		t.Error("typing the Back key into a filter went back a page")
+	}
+	if w = sendWizard(w, "esc", "?", "b"); w.Step() != 1 {
+		t.Error("pressing the Back key with help shown went back a page")
+	}
+	if w = sendWizard(w, "esc", "b"); w.Step() != 0 {
+		t.Errorf("pressing the Back key left the wizard on step %d", w.Step())
+	}
+}
@@ -0,0 +1,97 @@
+package gostructui
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValuesYAML marshals the current field values into a YAML mapping
+// keyed by the names of the struct fields, as read back by
+// LoadValuesYAML. Values are written as by ValuesJSON, save that
+// dates are YAML timestamps. Unlike ToYAML, yaml struct tags are
+// not consulted.
+func (m TModelStructMenu) ValuesYAML() ([]byte, error) {
+	return yaml.Marshal(m.values())
+}
+
+// LoadValuesYAML prefills the menu from a YAML mapping keyed by the
+// names of the struct fields, as written by ValuesYAML. It reads
+// values as LoadValuesJSON does, and dates as YAML timestamps.
+func (m *TModelStructMenu) LoadValuesYAML(data []byte) error {
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	return m.loadValues("YAML", slices.Sorted(maps.Keys(raw)), func(f *menuField, key string) error {
+		node := raw[key]
+		return f.loadYAML(&node)
+	})
+}
+
+// loadYAML sets the field to the YAML value given, which is left
+// alone if null.
+func (f *menuField) loadYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return nil
+	}
+	switch f.kind {
+	case FieldString:
+		var s string
+		if err := node.Decode(&s); err != nil {
+			return err
+		}
+		return f.setValue(s)
+	case FieldBool:
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return err
+		}
+		return f.setValue(b)
+	case FieldInt:
+		var n int64
+		if err := node.Decode(&n); err != nil {
+			return err
+		}
+		return f.setText(strconv.FormatInt(n, 10))
+	case FieldUint:
+		var n uint64
+		if err := node.Decode(&n); err != nil {
+			return err
+		}
+		return f.setText(strconv.FormatUint(n, 10))
+	case FieldTime:
+		var t time.Time
+		if err := node.Decode(&t); err != nil {
+			return err
+		}
+		return f.setValue(t)
+	case FieldDuration, FieldCustom:
+		var s string
+		if err := node.Decode(&s); err != nil {
+			return err
+		}
+		return f.setText(s)
+	case FieldList:
+		var list []string
+		if err := node.Decode(&list); err != nil {
+			return err
+		}
+		// empty entries are dropped, as when editing
+		if err := f.setValue(list); err != nil {
+			return err
+		}
+		f.commitList()
+	case FieldMap:
+		var m map[string]string
+		if err := node.Decode(&m); err != nil {
+			return err
+		}
+		return f.setValue(m)
+	}
+	return nil
+}
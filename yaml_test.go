@@ -0,0 +1,31 @@
+package gostructui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLoadValuesYAMLSetsFields(t *testing.T) {
+	empty := ""
+	saved, err := newTestMenu(t, &loadForm{Nick: &empty}).ValuesYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var obj loadForm
+	m := newTestMenu(t, &obj)
+	if err := m.LoadValuesYAML(saved); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.LoadValuesYAML([]byte("Tags: [a, '', b]\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ParseStruct(&obj); err != nil {
+		t.Fatal(err)
+	}
+	if obj.Nick == nil || *obj.Nick != "" {
+		t.Errorf("Nick saved as \"\" loaded as %v", obj.Nick)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(obj.Tags, want) {
+		t.Errorf("Tags = %q, want %q", obj.Tags, want)
+	}
+}